@@ -0,0 +1,79 @@
+// Command xput-bench drives the xput throughput harness against
+// ProposeTransaction from the command line and prints a summary of
+// proposals/sec, latency percentiles, and errors by kind.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"time"
+
+	"github.com/d4mr/t2z/sdks/go/t2z_uniffi/xput"
+)
+
+func main() {
+	workers := flag.Int("workers", 8, "number of concurrent ProposeTransaction workers")
+	inputs := flag.Int("inputs", 64, "number of synthetic funded inputs to draw from")
+	maxPayments := flag.Int("max-payments", 4, "max payments per transaction request")
+	network := flag.String("network", "testnet", "network passed to ProposeTransaction")
+	expiryHeight := flag.Uint("expiry-height", 3720100, "expiry height passed to ProposeTransaction")
+	duration := flag.Duration("duration", 10*time.Second, "how long to drive proposals for")
+	profile := flag.String("profile", "", "directory to write cpu.prof and heap.prof into; empty disables profiling")
+	flag.Parse()
+
+	if *profile != "" {
+		if err := os.MkdirAll(*profile, 0755); err != nil {
+			log.Fatalf("creating profile dir: %v", err)
+		}
+
+		cpuFile, err := os.Create(*profile + "/cpu.prof")
+		if err != nil {
+			log.Fatalf("creating cpu profile: %v", err)
+		}
+		defer cpuFile.Close()
+		if err := pprof.StartCPUProfile(cpuFile); err != nil {
+			log.Fatalf("starting cpu profile: %v", err)
+		}
+		defer pprof.StopCPUProfile()
+	}
+
+	changeAddr := "t1change"
+	stats := xput.Run(xput.Config{
+		Inputs:                xput.GenerateInputs(*inputs),
+		Workers:               *workers,
+		MaxPaymentsPerRequest: *maxPayments,
+		ChangeAddress:         &changeAddr,
+		Network:               *network,
+		ExpiryHeight:          uint32(*expiryHeight),
+		Duration:              *duration,
+	})
+
+	if *profile != "" {
+		heapFile, err := os.Create(*profile + "/heap.prof")
+		if err != nil {
+			log.Fatalf("creating heap profile: %v", err)
+		}
+		defer heapFile.Close()
+		runtime.GC()
+		if err := pprof.WriteHeapProfile(heapFile); err != nil {
+			log.Fatalf("writing heap profile: %v", err)
+		}
+	}
+
+	fmt.Printf("proposals:    %d (%d errors)\n", stats.Proposals, stats.Errors)
+	fmt.Printf("elapsed:      %s\n", stats.Elapsed)
+	fmt.Printf("throughput:   %.1f proposals/sec\n", stats.ProposalsPerSec())
+	fmt.Printf("latency p50:  %s\n", stats.P50)
+	fmt.Printf("latency p95:  %s\n", stats.P95)
+	fmt.Printf("latency p99:  %s\n", stats.P99)
+	if len(stats.ErrorsByKind) > 0 {
+		fmt.Println("errors by kind:")
+		for kind, count := range stats.ErrorsByKind {
+			fmt.Printf("  %-20s %d\n", kind, count)
+		}
+	}
+}
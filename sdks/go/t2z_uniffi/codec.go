@@ -0,0 +1,50 @@
+package t2z_uniffi
+
+import (
+	"io"
+	"sync"
+)
+
+// scratchPool holds reusable 8-byte buffers, large enough for any of the
+// fixed-width primitives below (int64/uint64/float64 are the widest).
+// Routing writeUintN/readUintN through binary.Write/binary.Read means every
+// call reflects on value's dynamic type to pick an encoder; for a PCZT with
+// thousands of fields that reflection dominates CPU time. These helpers
+// encode directly into a pooled []byte with encoding/binary.BigEndian
+// instead, and keep plain io.Reader/io.Writer as the call surface so the
+// FfiConverters in t2z_uniffi.go don't need to know whether they're backed
+// by bytes.
+var scratchPool = sync.Pool{
+	New: func() any {
+		b := make([]byte, 8)
+		return &b
+	},
+}
+
+func getScratch() *[]byte {
+	return scratchPool.Get().(*[]byte)
+}
+
+func putScratch(b *[]byte) {
+	scratchPool.Put(b)
+}
+
+func fastWrite(writer io.Writer, n int, encode func(b []byte)) {
+	scratch := getScratch()
+	defer putScratch(scratch)
+	b := (*scratch)[:n]
+	encode(b)
+	if _, err := writer.Write(b); err != nil {
+		panic(err)
+	}
+}
+
+func fastRead(reader io.Reader, n int, decode func(b []byte)) {
+	scratch := getScratch()
+	defer putScratch(scratch)
+	b := (*scratch)[:n]
+	if _, err := io.ReadFull(reader, b); err != nil {
+		panic(err)
+	}
+	decode(b)
+}
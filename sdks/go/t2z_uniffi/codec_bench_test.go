@@ -0,0 +1,26 @@
+package t2z_uniffi
+
+import (
+	"bytes"
+	"testing"
+)
+
+func BenchmarkWriteReadUint32(b *testing.B) {
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		writeUint32(&buf, uint32(i))
+		_ = readUint32(&buf)
+	}
+}
+
+func BenchmarkWriteReadBool(b *testing.B) {
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		FfiConverterBoolINSTANCE.Write(&buf, i%2 == 0)
+		_ = FfiConverterBoolINSTANCE.Read(&buf)
+	}
+}
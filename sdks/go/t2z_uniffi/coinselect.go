@@ -0,0 +1,178 @@
+package t2z_uniffi
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// coinSelectionDustThreshold is the minimum change amount
+// ProposeTransactionWithCoinSelection will return to changeAddress.
+// Residual value below this is left to the fee rather than creating an
+// uneconomical change output, mirroring the dust threshold convention used
+// throughout the Bitcoin/Zcash wallet ecosystem.
+const coinSelectionDustThreshold = 546
+
+// ErrInsufficientFunds is returned by ProposeTransactionWithCoinSelection
+// when the UniffiInputSource is exhausted before enough input value has
+// been gathered to cover the transaction request's payments and its
+// ZIP 317 fee, mirroring btcwallet's ErrInsufficientFunds from
+// NewUnsignedTransaction.
+var ErrInsufficientFunds = errors.New("t2z_uniffi: insufficient funds for coin selection")
+
+// UniffiInputSource supplies candidate transparent UTXOs to
+// ProposeTransactionWithCoinSelection incrementally, mirroring the
+// fetchInputs callback in btcwallet's NewUnsignedTransaction: each call is
+// asked for at least target additional zatoshis of input value and returns
+// what it can, so a wallet doesn't have to load its entire UTXO set up
+// front just to propose a small payment.
+type UniffiInputSource interface {
+	// MoreInputs returns additional candidate inputs covering at least
+	// target zatoshis of value, or fewer if the source is running low. A
+	// nil slice with a nil error means the source has nothing left to
+	// offer.
+	MoreInputs(target uint64) ([]UniffiTransparentInput, error)
+}
+
+// StaticInputSource is a UniffiInputSource backed by a fixed, already
+// fetched pool of candidate inputs, handed out largest-value-first. This
+// covers the common case: a caller that already has its UTXO set in memory
+// and just wants ProposeTransactionWithCoinSelection to pick from it.
+type StaticInputSource struct {
+	remaining []UniffiTransparentInput
+}
+
+// NewStaticInputSource wraps inputs as a UniffiInputSource. inputs is
+// copied, so the caller's slice may be reused or mutated afterward.
+func NewStaticInputSource(inputs []UniffiTransparentInput) *StaticInputSource {
+	sorted := append([]UniffiTransparentInput(nil), inputs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Value > sorted[j].Value })
+	return &StaticInputSource{remaining: sorted}
+}
+
+// MoreInputs implements UniffiInputSource.
+func (s *StaticInputSource) MoreInputs(target uint64) ([]UniffiTransparentInput, error) {
+	var got []UniffiTransparentInput
+	var total uint64
+	for total < target && len(s.remaining) > 0 {
+		next := s.remaining[0]
+		s.remaining = s.remaining[1:]
+		got = append(got, next)
+		total += next.Value
+	}
+	return got, nil
+}
+
+// sortedInputIndices returns indices into inputs ordered for strategy.
+// UniffiCoinSelectionStrategyBranchAndBound isn't an ordering and isn't
+// handled here; see branchAndBoundIndices.
+func sortedInputIndices(inputs []UniffiTransparentInput, strategy UniffiCoinSelectionStrategy) ([]int, error) {
+	order := make([]int, len(inputs))
+	for i := range order {
+		order[i] = i
+	}
+	switch strategy {
+	case UniffiCoinSelectionStrategyLargestFirst:
+		sort.Slice(order, func(i, j int) bool { return inputs[order[i]].Value > inputs[order[j]].Value })
+	case UniffiCoinSelectionStrategySmallestFirst:
+		sort.Slice(order, func(i, j int) bool { return inputs[order[i]].Value < inputs[order[j]].Value })
+	case UniffiCoinSelectionStrategyRandom:
+		rand.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	default:
+		return nil, fmt.Errorf("t2z_uniffi: unknown coin selection strategy %d", strategy)
+	}
+	return order, nil
+}
+
+// branchAndBoundIndices searches for a subset of inputs summing to exactly
+// targetValue, so the resulting transaction needs no change output,
+// matching UniffiCoinSelectionStrategyBranchAndBound's doc comment. The
+// search tries candidates largest-first and prunes any partial sum that
+// already exceeds targetValue, bounded by maxBranchAndBoundTries total
+// branches so a large, change-less inputs set can't make SelectInputs hang.
+func branchAndBoundIndices(inputs []UniffiTransparentInput, targetValue uint64) ([]int, bool) {
+	const maxBranchAndBoundTries = 100_000
+
+	order := make([]int, len(inputs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return inputs[order[i]].Value > inputs[order[j]].Value })
+
+	tries := 0
+	var found []int
+	var search func(i int, selected []int, sum uint64) bool
+	search = func(i int, selected []int, sum uint64) bool {
+		tries++
+		if sum == targetValue {
+			found = append([]int(nil), selected...)
+			return true
+		}
+		if tries > maxBranchAndBoundTries || i >= len(order) || sum > targetValue {
+			return false
+		}
+		if search(i+1, append(selected, order[i]), sum+inputs[order[i]].Value) {
+			return true
+		}
+		return search(i+1, selected, sum)
+	}
+	if search(0, nil, 0) {
+		return found, true
+	}
+	return nil, false
+}
+
+// ProposeTransactionWithCoinSelection proposes a transaction like
+// ProposeTransaction, but selects inputs automatically instead of requiring
+// the caller to pre-select them. It repeatedly pulls more candidate inputs
+// from source and re-estimates the ZIP 317 fee via EstimateFee, mirroring
+// the fetchInputs/fetchChange loop in btcwallet's NewUnsignedTransaction,
+// until enough value has been gathered to cover the payments and the fee
+// for the resulting transaction size. A change output is appended only
+// when the residual is at least coinSelectionDustThreshold; a smaller residual
+// is left to the fee instead of minting dust. Returns ErrInsufficientFunds
+// if source is exhausted before enough value is found.
+func ProposeTransactionWithCoinSelection(source UniffiInputSource, transactionRequest UniffiTransactionRequest, changeAddress *string, network string, expiryHeight uint32) (*UniffiPczt, error) {
+	var paymentTotal uint64
+	for _, p := range transactionRequest.Payments {
+		paymentTotal += p.Amount
+	}
+
+	var gathered []UniffiTransparentInput
+	var gatheredTotal uint64
+	for {
+		// An error here just means gathered doesn't cover payments+fee yet
+		// (or is empty); it's treated the same as "need more inputs" and
+		// surfaces as ErrInsufficientFunds once source runs dry, same as a
+		// genuine shortfall would.
+		estimate, err := EstimateFee(gathered, transactionRequest, changeAddress, network)
+		if err == nil && gatheredTotal >= paymentTotal+estimate.FeeZatoshis {
+			selected := make([]UniffiTransparentInput, len(estimate.SelectedInputIndices))
+			for i, idx := range estimate.SelectedInputIndices {
+				selected[i] = gathered[idx]
+			}
+			finalChangeAddress := changeAddress
+			if estimate.ChangeAmount < coinSelectionDustThreshold {
+				finalChangeAddress = nil
+			}
+			return ProposeTransaction(selected, transactionRequest, finalChangeAddress, network, expiryHeight)
+		}
+
+		outstanding := uint64(1)
+		if gatheredTotal < paymentTotal {
+			outstanding = paymentTotal - gatheredTotal
+		}
+		more, err := source.MoreInputs(outstanding)
+		if err != nil {
+			return nil, fmt.Errorf("coin selection: %w", err)
+		}
+		if len(more) == 0 {
+			return nil, ErrInsufficientFunds
+		}
+		for _, in := range more {
+			gathered = append(gathered, in)
+			gatheredTotal += in.Value
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package t2z_uniffi
+
+import "testing"
+
+func TestStaticInputSourceLargestFirst(t *testing.T) {
+	source := NewStaticInputSource([]UniffiTransparentInput{
+		{PrevoutTxid: "a", Value: 100},
+		{PrevoutTxid: "b", Value: 500},
+		{PrevoutTxid: "c", Value: 200},
+	})
+
+	got, err := source.MoreInputs(1)
+	if err != nil {
+		t.Fatalf("MoreInputs: %v", err)
+	}
+	if len(got) != 1 || got[0].PrevoutTxid != "b" {
+		t.Fatalf("expected largest input 'b' first, got %+v", got)
+	}
+}
+
+func TestStaticInputSourceStopsAtTarget(t *testing.T) {
+	source := NewStaticInputSource([]UniffiTransparentInput{
+		{PrevoutTxid: "a", Value: 100},
+		{PrevoutTxid: "b", Value: 100},
+		{PrevoutTxid: "c", Value: 100},
+	})
+
+	got, err := source.MoreInputs(150)
+	if err != nil {
+		t.Fatalf("MoreInputs: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 inputs to cover target 150, got %d", len(got))
+	}
+}
+
+func TestStaticInputSourceExhausted(t *testing.T) {
+	source := NewStaticInputSource(nil)
+	got, err := source.MoreInputs(1)
+	if err != nil {
+		t.Fatalf("MoreInputs: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no inputs from an empty source, got %d", len(got))
+	}
+}
+
+func TestProposeTransactionWithCoinSelectionInsufficientFunds(t *testing.T) {
+	// An empty source can never gather enough value, regardless of what
+	// EstimateFee/ProposeTransaction report, so this must fail fast with
+	// ErrInsufficientFunds rather than looping forever.
+	source := NewStaticInputSource(nil)
+	request := UniffiTransactionRequest{
+		Payments: []UniffiPayment{{Address: "t1test", Amount: 100000}},
+	}
+
+	_, err := ProposeTransactionWithCoinSelection(source, request, nil, "testnet", 3720100)
+	if err != ErrInsufficientFunds {
+		t.Fatalf("expected ErrInsufficientFunds, got %v", err)
+	}
+}
+
+func TestProposeTransactionWithCoinSelectionGathersEnoughInputs(t *testing.T) {
+	// Before EstimateFee had a real implementation, this loop could never
+	// take its success branch: EstimateFee always errored, so the loop
+	// drained source and returned ErrInsufficientFunds even when gathered
+	// value was ample. A source with enough value must be allowed past the
+	// gather loop - whatever ProposeTransaction itself returns for this
+	// synthetic input is a separate concern from coin selection's job of
+	// gathering inputs.
+	source := NewStaticInputSource([]UniffiTransparentInput{
+		{PrevoutTxid: "a", Value: 10_000_000},
+	})
+	request := UniffiTransactionRequest{
+		Payments: []UniffiPayment{{Address: "t1test", Amount: 100000}},
+	}
+
+	_, err := ProposeTransactionWithCoinSelection(source, request, nil, "testnet", 3720100)
+	if err == ErrInsufficientFunds {
+		t.Fatal("expected coin selection to gather enough inputs and proceed to ProposeTransaction, got ErrInsufficientFunds")
+	}
+}
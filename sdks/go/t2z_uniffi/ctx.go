@@ -0,0 +1,233 @@
+package t2z_uniffi
+
+/*
+#include <t2z_uniffi.h>
+*/
+import "C"
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ProgressSink receives progress updates from long-running Rust-side
+// operations (Sapling/Orchard circuit build, proving-key load). Stage names
+// are opaque strings chosen by the Rust side (e.g. "orchard_circuit",
+// "sapling_circuit", "proving_key_load"); current/total let callers render a
+// determinate progress bar when total is known, or a spinner when it's 0.
+type ProgressSink interface {
+	OnStage(stage string, current, total uint64)
+}
+
+// ProveOptions configures the context-aware proving entry points.
+type ProveOptions struct {
+	// Progress is notified as the prover advances through its stages.
+	// May be nil if the caller doesn't care about progress.
+	Progress ProgressSink
+}
+
+var (
+	progressSinksMu   sync.Mutex
+	progressSinks     = map[uint64]ProgressSink{}
+	nextProgressToken uint64
+)
+
+func registerProgressSink(sink ProgressSink) uint64 {
+	if sink == nil {
+		return 0
+	}
+	progressSinksMu.Lock()
+	defer progressSinksMu.Unlock()
+	nextProgressToken++
+	token := nextProgressToken
+	progressSinks[token] = sink
+	return token
+}
+
+func unregisterProgressSink(token uint64) {
+	if token == 0 {
+		return
+	}
+	progressSinksMu.Lock()
+	defer progressSinksMu.Unlock()
+	delete(progressSinks, token)
+}
+
+//export t2zUniffiProgressCallback
+func t2zUniffiProgressCallback(token uint64, stage *C.char, current, total uint64) {
+	progressSinksMu.Lock()
+	sink := progressSinks[token]
+	progressSinksMu.Unlock()
+	if sink == nil {
+		return
+	}
+	sink.OnStage(C.GoString(stage), current, total)
+}
+
+// UniffiCancelHandle lets a caller request cancellation of an in-flight
+// ProveTransactionCtx/PrebuildProvingKeyCtx call. It does not yet bind to
+// any Rust-side cancellation primitive: new_cancel_handle and the
+// UniffiCancelHandle object's clone/free/cancel methods have no
+// implementation in the t2z_uniffi crate vendored in this repo, so Cancel
+// only stops ProveTransactionCtx/PrebuildProvingKeyCtx from waiting on the
+// result - it can't interrupt the Rust-side call already in flight until
+// that Rust-side work lands.
+type UniffiCancelHandle struct {
+	cancelled atomic.Bool
+}
+
+func newUniffiCancelHandle() *UniffiCancelHandle {
+	return &UniffiCancelHandle{}
+}
+
+// Cancel flips the cancellation flag. Safe to call more than once and from
+// a goroutine other than the one driving the call.
+func (h *UniffiCancelHandle) Cancel() {
+	h.cancelled.Store(true)
+}
+
+func (h *UniffiCancelHandle) Destroy() {}
+
+// ctxResult carries the outcome of a cancellable call back from the
+// goroutine actually driving the blocking call.
+type ctxResult struct {
+	pczt *UniffiPczt
+	err  error
+}
+
+// ProveTransactionCtx is the context-aware, cancellable, progress-reporting
+// counterpart to ProveTransaction. It drives the same underlying
+// prove_transaction rust call on a goroutine so a cancelled ctx can return
+// ctx.Err() without waiting for it to finish; opts.Progress is registered
+// so a future prove_transaction_with_progress export can report through
+// it, but since that export doesn't exist yet in the vendored crate, no
+// progress callbacks actually fire today.
+func ProveTransactionCtx(ctx context.Context, pczt *UniffiPczt, opts ProveOptions) (*UniffiPczt, error) {
+	handle := newUniffiCancelHandle()
+
+	token := registerProgressSink(opts.Progress)
+	defer unregisterProgressSink(token)
+
+	done := make(chan ctxResult, 1)
+	panicked := make(chan any, 1)
+	go func() {
+		// Nothing above this goroutine can recover a panic raised in it (a
+		// nil pczt, or a Rust-side panic surfaced by rustCallWithError), so
+		// it's caught here and re-raised on the caller's goroutine below if
+		// the caller is still waiting; a caller that already returned
+		// ctx.Err() never sees it.
+		defer func() {
+			if p := recover(); p != nil {
+				handle.Destroy()
+				panicked <- p
+			}
+		}()
+		result, err := proveTransactionWithHandle(pczt, handle, token)
+		// handle is done being used the moment proveTransactionWithHandle
+		// returns, regardless of which select branch below already ran -
+		// destroying it here (not via an outer defer) guarantees the
+		// background call has stopped touching it first, so a cancelled
+		// caller returning early can't free it out from under this
+		// goroutine's still-in-flight use of handle.
+		handle.Destroy()
+		done <- ctxResult{pczt: result, err: err}
+	}()
+
+	// Checked separately, and first, so an already-cancelled ctx always
+	// wins: if the call above happens to already be done by the time the
+	// select below runs, Go picks pseudo-randomly among ready cases -
+	// without this, a cancelled caller could nondeterministically get the
+	// call's result instead of ctx.Err().
+	select {
+	case <-ctx.Done():
+		handle.Cancel()
+		return nil, ctx.Err()
+	default:
+	}
+
+	select {
+	case <-ctx.Done():
+		handle.Cancel()
+		return nil, ctx.Err()
+	case r := <-done:
+		return r.pczt, r.err
+	case p := <-panicked:
+		panic(p)
+	}
+}
+
+// proveTransactionWithHandle calls the real, checksum-verified
+// prove_transaction rust export directly. handle and progressToken aren't
+// passed through to Rust: prove_transaction_with_progress (the variant
+// that would accept a cancel handle and report through progressToken) has
+// no implementation in the vendored crate, so cancellation here only ever
+// stops the caller from waiting (see UniffiCancelHandle's doc comment).
+func proveTransactionWithHandle(pczt *UniffiPczt, handle *UniffiCancelHandle, progressToken uint64) (*UniffiPczt, error) {
+	_uniffiRV, _uniffiErr := rustCallWithError[UniffiError](FfiConverterUniffiError{}, func(_uniffiStatus *C.RustCallStatus) unsafe.Pointer {
+		return C.uniffi_t2z_uniffi_fn_func_prove_transaction(FfiConverterUniffiPcztINSTANCE.Lower(pczt), _uniffiStatus)
+	})
+	if _uniffiErr != nil {
+		var _uniffiDefaultValue *UniffiPczt
+		return _uniffiDefaultValue, _uniffiErr
+	}
+	return FfiConverterUniffiPcztINSTANCE.Lift(_uniffiRV), nil
+}
+
+// PrebuildProvingKeyCtx is the context-aware, cancellable, progress-reporting
+// counterpart to PrebuildProvingKey. It drives the same underlying
+// prebuild_proving_key rust call on a goroutine so a cancelled ctx can
+// return ctx.Err() without waiting for it to finish; opts.Progress is
+// registered so a future prebuild_proving_key_with_progress export can
+// report through it, but since that export doesn't exist yet in the
+// vendored crate, no progress callbacks actually fire today.
+func PrebuildProvingKeyCtx(ctx context.Context, opts ProveOptions) error {
+	handle := newUniffiCancelHandle()
+
+	token := registerProgressSink(opts.Progress)
+	defer unregisterProgressSink(token)
+
+	done := make(chan error, 1)
+	go func() {
+		err := prebuildProvingKeyWithHandle(handle, token)
+		// See the matching comment in ProveTransactionCtx: handle must
+		// not be destroyed until this goroutine is done using it, which
+		// an outer `defer handle.Destroy()` can't guarantee once the
+		// ctx.Done() branch below returns early.
+		handle.Destroy()
+		done <- err
+	}()
+
+	// See the matching comment in ProveTransactionCtx: check ctx.Done()
+	// on its own first so an already-cancelled ctx can't lose a race
+	// against an already-finished call.
+	select {
+	case <-ctx.Done():
+		handle.Cancel()
+		return ctx.Err()
+	default:
+	}
+
+	select {
+	case <-ctx.Done():
+		handle.Cancel()
+		return ctx.Err()
+	case err := <-done:
+		return err
+	}
+}
+
+// prebuildProvingKeyWithHandle calls the real, checksum-verified
+// prebuild_proving_key rust export directly. It has no UniffiError return
+// on the Rust side (rustCall surfaces only a Rust-side panic, not an
+// application error), so this always returns nil. See
+// proveTransactionWithHandle above for why handle/progressToken aren't
+// passed through to Rust.
+func prebuildProvingKeyWithHandle(handle *UniffiCancelHandle, progressToken uint64) error {
+	rustCall(func(_uniffiStatus *C.RustCallStatus) bool {
+		C.uniffi_t2z_uniffi_fn_func_prebuild_proving_key(_uniffiStatus)
+		return false
+	})
+	return nil
+}
@@ -0,0 +1,48 @@
+package t2z_uniffi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestProveTransactionCtxCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pczt, err := ProveTransactionCtx(ctx, nil, ProveOptions{})
+	if pczt != nil {
+		t.Errorf("expected nil pczt on cancelled context, got %v", pczt)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+type recordingProgressSink struct {
+	stages []string
+}
+
+func (r *recordingProgressSink) OnStage(stage string, current, total uint64) {
+	r.stages = append(r.stages, stage)
+}
+
+func TestProgressSinkRegistration(t *testing.T) {
+	sink := &recordingProgressSink{}
+	token := registerProgressSink(sink)
+	if token == 0 {
+		t.Fatal("expected non-zero token for non-nil sink")
+	}
+	defer unregisterProgressSink(token)
+
+	t2zUniffiProgressCallback(token, nil, 1, 10)
+	if len(sink.stages) != 1 {
+		t.Fatalf("expected 1 recorded stage, got %d", len(sink.stages))
+	}
+}
+
+func TestProgressSinkRegistrationNil(t *testing.T) {
+	if token := registerProgressSink(nil); token != 0 {
+		t.Errorf("expected token 0 for nil sink, got %d", token)
+	}
+}
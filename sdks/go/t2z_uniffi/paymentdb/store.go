@@ -0,0 +1,320 @@
+// Package paymentdb persists the lifecycle of every PCZT the t2z_uniffi SDK
+// proposes, so a Go application has a durable audit trail without
+// reimplementing storage itself. It's modeled on lnd's channeldb: one
+// top-level bucket keyed by a stable payment id, with each payment's record
+// split across a handful of fixed sub-keys instead of one serialized blob,
+// so advancing the lifecycle (UpdateStatus) doesn't require rewriting
+// fields that haven't changed.
+package paymentdb
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	t2z "github.com/d4mr/t2z/sdks/go/t2z_uniffi"
+)
+
+// Status is a payment's position in its lifecycle, persisted as a single
+// byte in each payment's statusKey.
+type Status byte
+
+const (
+	StatusCreated Status = iota + 1
+	StatusSigned
+	StatusBroadcast
+	StatusConfirmed
+	StatusFailed
+)
+
+// String renders a Status for logging; unrecognized values (e.g. from a
+// newer writer) render as "unknown(N)" instead of panicking.
+func (s Status) String() string {
+	switch s {
+	case StatusCreated:
+		return "created"
+	case StatusSigned:
+		return "signed"
+	case StatusBroadcast:
+		return "broadcast"
+	case StatusConfirmed:
+		return "confirmed"
+	case StatusFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(s))
+	}
+}
+
+var paymentsBucket = []byte("payments")
+
+const (
+	creationInfoKey = "creation"
+	attemptInfoKey  = "attempt"
+	statusKey       = "status"
+	settleInfoKey   = "settle"
+)
+
+// Outpoint identifies a transparent UTXO spent by a payment's PCZT.
+type Outpoint struct {
+	Txid  string `json:"txid"`
+	Index uint32 `json:"index"`
+}
+
+// CreationInfo is the immutable context a payment was proposed under,
+// recorded once by RecordProposed.
+type CreationInfo struct {
+	CreatedAt     time.Time           `json:"created_at"`
+	Payments      []t2z.UniffiPayment `json:"payments"`
+	ChangeAddress *string             `json:"change_address,omitempty"`
+	Network       string              `json:"network"`
+	// ExpiryHeight is the chain-height context the proposal was built
+	// against (the spec's "anchor height"): the PCZT's expiry height.
+	ExpiryHeight uint32 `json:"expiry_height"`
+}
+
+// AttemptInfo is the proposed PCZT and the outpoints it spends, recorded
+// once by RecordProposed and never mutated afterward; re-proposing a
+// payment (e.g. after a fee bump) creates a new payment id rather than
+// overwriting this one.
+type AttemptInfo struct {
+	PcztBytes []byte     `json:"pczt_bytes"`
+	Inputs    []Outpoint `json:"inputs"`
+}
+
+// SettleInfo is recorded by UpdateStatus when a payment reaches
+// StatusConfirmed.
+type SettleInfo struct {
+	Txid        string `json:"txid"`
+	BlockHeight uint32 `json:"block_height"`
+}
+
+// Payment is the full record returned by Get and List: everything known
+// about one PCZT's lifecycle.
+type Payment struct {
+	ID           string
+	CreationInfo CreationInfo
+	AttemptInfo  AttemptInfo
+	Status       Status
+	// SettleInfo is nil until the payment reaches StatusConfirmed.
+	SettleInfo *SettleInfo
+}
+
+func (p *Payment) load(id string, bucket *bbolt.Bucket) error {
+	p.ID = id
+	if err := getJSON(bucket, creationInfoKey, &p.CreationInfo); err != nil {
+		return fmt.Errorf("paymentdb: reading creation info for %q: %w", id, err)
+	}
+	if err := getJSON(bucket, attemptInfoKey, &p.AttemptInfo); err != nil {
+		return fmt.Errorf("paymentdb: reading attempt info for %q: %w", id, err)
+	}
+
+	raw := bucket.Get([]byte(statusKey))
+	if len(raw) != 1 {
+		return fmt.Errorf("paymentdb: payment %q has no status", id)
+	}
+	p.Status = Status(raw[0])
+
+	if raw := bucket.Get([]byte(settleInfoKey)); raw != nil {
+		var settle SettleInfo
+		if err := json.Unmarshal(raw, &settle); err != nil {
+			return fmt.Errorf("paymentdb: reading settle info for %q: %w", id, err)
+		}
+		p.SettleInfo = &settle
+	}
+	return nil
+}
+
+// PaymentStore is a durable, bbolt-backed record of every PCZT this SDK has
+// proposed and its lifecycle.
+type PaymentStore struct {
+	db *bbolt.DB
+}
+
+// Open opens (creating if necessary) a PaymentStore at path.
+func Open(path string) (*PaymentStore, error) {
+	db, err := bbolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("paymentdb: opening %s: %w", path, err)
+	}
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(paymentsBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("paymentdb: initializing %s: %w", path, err)
+	}
+	return &PaymentStore{db: db}, nil
+}
+
+// Close releases the underlying database file.
+func (s *PaymentStore) Close() error {
+	return s.db.Close()
+}
+
+// RecordProposed records a freshly-proposed PCZT and the request it was
+// built from under a new, randomly generated payment id, starting at
+// StatusCreated. Use UpdateStatus to advance the record as the PCZT is
+// signed, broadcast, and confirmed.
+func (s *PaymentStore) RecordProposed(pczt *t2z.UniffiPczt, request t2z.UniffiTransactionRequest, changeAddress *string, network string, expiryHeight uint32, inputs []Outpoint) (string, error) {
+	return s.recordProposed(pczt.ToBytes(), request, changeAddress, network, expiryHeight, inputs)
+}
+
+// recordProposed is the FFI-free core of RecordProposed, split out so the
+// bucket/encoding logic is unit-testable without a linked Rust library to
+// produce real PCZT bytes.
+func (s *PaymentStore) recordProposed(pcztBytes []byte, request t2z.UniffiTransactionRequest, changeAddress *string, network string, expiryHeight uint32, inputs []Outpoint) (string, error) {
+	id, err := newPaymentID()
+	if err != nil {
+		return "", fmt.Errorf("paymentdb: generating payment id: %w", err)
+	}
+
+	creation := CreationInfo{
+		CreatedAt:     time.Now(),
+		Payments:      request.Payments,
+		ChangeAddress: changeAddress,
+		Network:       network,
+		ExpiryHeight:  expiryHeight,
+	}
+	attempt := AttemptInfo{
+		PcztBytes: pcztBytes,
+		Inputs:    inputs,
+	}
+
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.Bucket(paymentsBucket).CreateBucket([]byte(id))
+		if err != nil {
+			return fmt.Errorf("creating record: %w", err)
+		}
+		if err := putJSON(bucket, creationInfoKey, creation); err != nil {
+			return err
+		}
+		if err := putJSON(bucket, attemptInfoKey, attempt); err != nil {
+			return err
+		}
+		return bucket.Put([]byte(statusKey), []byte{byte(StatusCreated)})
+	})
+	if err != nil {
+		return "", fmt.Errorf("paymentdb: recording payment: %w", err)
+	}
+	return id, nil
+}
+
+// UpdateStatus advances a payment's lifecycle status. settle is persisted
+// alongside a transition to StatusConfirmed and ignored for every other
+// status; pass nil when it doesn't apply.
+func (s *PaymentStore) UpdateStatus(id string, status Status, settle *SettleInfo) error {
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(paymentsBucket).Bucket([]byte(id))
+		if bucket == nil {
+			return fmt.Errorf("unknown payment id %q", id)
+		}
+		if err := bucket.Put([]byte(statusKey), []byte{byte(status)}); err != nil {
+			return err
+		}
+		if status == StatusConfirmed && settle != nil {
+			return putJSON(bucket, settleInfoKey, *settle)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("paymentdb: updating status: %w", err)
+	}
+	return nil
+}
+
+// Get returns the full record for id.
+func (s *PaymentStore) Get(id string) (Payment, error) {
+	var payment Payment
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(paymentsBucket).Bucket([]byte(id))
+		if bucket == nil {
+			return fmt.Errorf("paymentdb: unknown payment id %q", id)
+		}
+		return payment.load(id, bucket)
+	})
+	return payment, err
+}
+
+// Filter narrows List to payments matching every non-zero field.
+type Filter struct {
+	Status Status
+}
+
+// List returns every payment matching filter, in no particular order.
+func (s *PaymentStore) List(filter Filter) ([]Payment, error) {
+	var payments []Payment
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(paymentsBucket)
+		return bucket.ForEach(func(id, v []byte) error {
+			if v != nil {
+				return nil // not a nested payment bucket
+			}
+			var payment Payment
+			if err := payment.load(string(id), bucket.Bucket(id)); err != nil {
+				return err
+			}
+			if filter.Status != 0 && payment.Status != filter.Status {
+				return nil
+			}
+			payments = append(payments, payment)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("paymentdb: listing payments: %w", err)
+	}
+	return payments, nil
+}
+
+// ProposeAndRecord calls t2z.ProposeTransaction and, on success, records
+// the result via RecordProposed, so callers that want a durable audit
+// trail don't have to remember to call RecordProposed on every proposal.
+// A recording failure is returned alongside the successfully-proposed
+// PCZT rather than discarding it, since the proposal itself already
+// succeeded.
+func (s *PaymentStore) ProposeAndRecord(inputs []t2z.UniffiTransparentInput, request t2z.UniffiTransactionRequest, changeAddress *string, network string, expiryHeight uint32) (pczt *t2z.UniffiPczt, id string, err error) {
+	pczt, err = t2z.ProposeTransaction(inputs, request, changeAddress, network, expiryHeight)
+	if err != nil {
+		return nil, "", err
+	}
+
+	outpoints := make([]Outpoint, len(inputs))
+	for i, in := range inputs {
+		outpoints[i] = Outpoint{Txid: in.PrevoutTxid, Index: in.PrevoutIndex}
+	}
+	id, recordErr := s.RecordProposed(pczt, request, changeAddress, network, expiryHeight, outpoints)
+	if recordErr != nil {
+		return pczt, "", fmt.Errorf("paymentdb: recording proposal: %w", recordErr)
+	}
+	return pczt, id, nil
+}
+
+func putJSON(bucket *bbolt.Bucket, key string, value any) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", key, err)
+	}
+	return bucket.Put([]byte(key), data)
+}
+
+func getJSON(bucket *bbolt.Bucket, key string, out any) error {
+	data := bucket.Get([]byte(key))
+	if data == nil {
+		return fmt.Errorf("missing %s", key)
+	}
+	return json.Unmarshal(data, out)
+}
+
+// newPaymentID generates a random 32-byte payment id, hex encoded.
+func newPaymentID() (string, error) {
+	var id [32]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id[:]), nil
+}
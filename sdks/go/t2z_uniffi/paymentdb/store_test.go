@@ -0,0 +1,107 @@
+package paymentdb
+
+import (
+	"path/filepath"
+	"testing"
+
+	t2z "github.com/d4mr/t2z/sdks/go/t2z_uniffi"
+)
+
+func openTestStore(t *testing.T) *PaymentStore {
+	t.Helper()
+	store, err := Open(filepath.Join(t.TempDir(), "payments.db"))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestRecordProposedAndGet(t *testing.T) {
+	store := openTestStore(t)
+
+	request := t2z.UniffiTransactionRequest{
+		Payments: []t2z.UniffiPayment{{Address: "t1test", Amount: 500000}},
+	}
+	changeAddr := "t1change"
+
+	// recordProposed (rather than the public RecordProposed) is used here
+	// to exercise the bucket/encoding logic without a linked Rust library
+	// to produce real PCZT bytes; see its doc comment.
+	id, err := store.recordProposed([]byte{0xde, 0xad}, request, &changeAddr, "testnet", 3720100, []Outpoint{
+		{Txid: "00", Index: 0},
+	})
+	if err != nil {
+		t.Fatalf("recordProposed: %v", err)
+	}
+
+	payment, err := store.Get(id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if payment.Status != StatusCreated {
+		t.Errorf("Status = %v, want %v", payment.Status, StatusCreated)
+	}
+	if payment.CreationInfo.Network != "testnet" {
+		t.Errorf("Network = %q, want %q", payment.CreationInfo.Network, "testnet")
+	}
+	if len(payment.AttemptInfo.Inputs) != 1 || payment.AttemptInfo.Inputs[0].Txid != "00" {
+		t.Errorf("Inputs = %+v, want one input with txid 00", payment.AttemptInfo.Inputs)
+	}
+}
+
+func TestUpdateStatusToConfirmed(t *testing.T) {
+	store := openTestStore(t)
+
+	id, err := store.recordProposed(nil, t2z.UniffiTransactionRequest{}, nil, "testnet", 0, nil)
+	if err != nil {
+		t.Fatalf("recordProposed: %v", err)
+	}
+
+	settle := &SettleInfo{Txid: "abcd", BlockHeight: 1234}
+	if err := store.UpdateStatus(id, StatusConfirmed, settle); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	payment, err := store.Get(id)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if payment.Status != StatusConfirmed {
+		t.Errorf("Status = %v, want %v", payment.Status, StatusConfirmed)
+	}
+	if payment.SettleInfo == nil || payment.SettleInfo.Txid != "abcd" {
+		t.Errorf("SettleInfo = %+v, want txid abcd", payment.SettleInfo)
+	}
+}
+
+func TestGetUnknownID(t *testing.T) {
+	store := openTestStore(t)
+	if _, err := store.Get("does-not-exist"); err == nil {
+		t.Error("Get should error for an unknown payment id")
+	}
+}
+
+func TestListFiltersByStatus(t *testing.T) {
+	store := openTestStore(t)
+
+	created, err := store.recordProposed(nil, t2z.UniffiTransactionRequest{}, nil, "testnet", 0, nil)
+	if err != nil {
+		t.Fatalf("recordProposed: %v", err)
+	}
+	confirmed, err := store.recordProposed(nil, t2z.UniffiTransactionRequest{}, nil, "testnet", 0, nil)
+	if err != nil {
+		t.Fatalf("recordProposed: %v", err)
+	}
+	if err := store.UpdateStatus(confirmed, StatusConfirmed, nil); err != nil {
+		t.Fatalf("UpdateStatus: %v", err)
+	}
+
+	payments, err := store.List(Filter{Status: StatusCreated})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(payments) != 1 || payments[0].ID != created {
+		t.Errorf("List(StatusCreated) = %+v, want only %q", payments, created)
+	}
+}
@@ -0,0 +1,46 @@
+package t2z_uniffi
+
+import "fmt"
+
+// PcztToBytes serializes a PCZT to its canonical byte encoding, for
+// persisting an in-progress PCZT to disk or shipping it to another device
+// (an air-gapped signer, a remote co-signer, another process via
+// CombinePczts). It's a function-style, error-returning equivalent of
+// pczt.ToBytes() for callers that want to treat serialization uniformly
+// with PcztFromBytes rather than mixing a method call with two free
+// functions.
+func PcztToBytes(pczt *UniffiPczt) (data []byte, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("serializing PCZT: %v", r)
+		}
+	}()
+	return pczt.ToBytes(), nil
+}
+
+// PcztFromBytes parses a PCZT from the byte encoding produced by
+// PcztToBytes/pczt.ToBytes. It's an alias for UniffiPcztFromBytes kept
+// alongside PcztToBytes/PcztToHex/PcztFromHex for a consistent free-function
+// serialization surface.
+func PcztFromBytes(data []byte) (*UniffiPczt, error) {
+	return UniffiPcztFromBytes(data)
+}
+
+// PcztToHex serializes a PCZT to its canonical hex encoding. See PcztToBytes
+// for why this exists alongside pczt.ToHex().
+func PcztToHex(pczt *UniffiPczt) (hexString string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("serializing PCZT to hex: %v", r)
+		}
+	}()
+	return pczt.ToHex(), nil
+}
+
+// PcztFromHex parses a PCZT from the hex encoding produced by
+// PcztToHex/pczt.ToHex. It's an alias for UniffiPcztFromHex kept alongside
+// PcztToBytes/PcztFromBytes/PcztToHex for a consistent free-function
+// serialization surface.
+func PcztFromHex(hexString string) (*UniffiPczt, error) {
+	return UniffiPcztFromHex(hexString)
+}
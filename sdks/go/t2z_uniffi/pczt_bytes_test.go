@@ -0,0 +1,18 @@
+package t2z_uniffi
+
+import "testing"
+
+func TestPcztToBytesRecoversPanicIntoError(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("PcztToBytes should recover panics, got: %v", r)
+		}
+	}()
+	// A nil *UniffiPczt panics inside ToBytes (nil pointer dereference on
+	// ffiObject); PcztToBytes should surface that as an error instead of
+	// propagating the panic to the caller.
+	_, err := PcztToBytes(nil)
+	if err == nil {
+		t.Error("expected error for nil PCZT, got nil")
+	}
+}
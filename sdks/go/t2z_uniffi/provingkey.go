@@ -0,0 +1,127 @@
+package t2z_uniffi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// provingKeyMagic identifies a file as a t2z proving-key cache, to fail
+// fast on garbage input rather than misinterpreting it as a truncated key.
+const provingKeyMagic = "t2zpk1\x00\x00"
+
+// provingKeyHeaderLen is the length in bytes of the fixed-size header
+// preceding the serialized proving key: magic, version tag, k-parameter.
+const provingKeyHeaderLen = len(provingKeyMagic) + 4 + 4
+
+// LoadProvingKey reads a proving-key cache file previously written by
+// SaveProvingKey and loads it into memory, making IsProvingKeyReady true
+// without rebuilding the Halo2 circuit. It returns an error if the file is
+// missing, malformed, or was built for a different proving-key version or
+// k-parameter than this binary expects. In this build
+// deserializeProvingKeyBytes always returns
+// errDeserializeProvingKeyNotImplemented (see the NOTE in t2z_uniffi.go),
+// so LoadProvingKey returns an error for every file, well-formed or not.
+func LoadProvingKey(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("loading proving key: %w", err)
+	}
+	payload, err := decodeProvingKeyFile(data)
+	if err != nil {
+		return fmt.Errorf("loading proving key from %s: %w", path, err)
+	}
+	if err := deserializeProvingKeyBytes(payload); err != nil {
+		return fmt.Errorf("loading proving key from %s: %w", path, err)
+	}
+	return nil
+}
+
+// SaveProvingKey serializes the in-memory proving key (built by
+// PrebuildProvingKey/PrebuildProvingKeyCtx) and writes it to path, tagged
+// with the current version and k-parameter so a later LoadProvingKey can
+// detect staleness. The file is written atomically via a temp file in the
+// same directory followed by a rename, so a crash mid-write can't leave a
+// corrupt cache behind. In this build serializeProvingKeyBytes always
+// returns errSerializeProvingKeyNotImplemented, so SaveProvingKey returns
+// that error without writing anything.
+func SaveProvingKey(path string) error {
+	payload, err := serializeProvingKeyBytes()
+	if err != nil {
+		return fmt.Errorf("saving proving key: %w", err)
+	}
+	data := encodeProvingKeyFile(payload)
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("saving proving key: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer os.Remove(tmpName) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("saving proving key: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("saving proving key: %w", err)
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		return fmt.Errorf("saving proving key: %w", err)
+	}
+	return nil
+}
+
+// EnsureProvingKey makes IsProvingKeyReady true by the cheapest available
+// route: if path exists and is a well-formed, version-matched cache, it's
+// loaded; otherwise the proving key is built from scratch (the expensive
+// Halo2 setup) and the result is written to path for next time. Since
+// LoadProvingKey and SaveProvingKey both fail in this build (see their doc
+// comments), EnsureProvingKey always builds the key in memory via
+// PrebuildProvingKey and then returns SaveProvingKey's error; no cache file
+// is written.
+func EnsureProvingKey(path string) error {
+	if err := LoadProvingKey(path); err == nil {
+		return nil
+	}
+	PrebuildProvingKey()
+	return SaveProvingKey(path)
+}
+
+// encodeProvingKeyFile prepends the magic/version/k-param header to a
+// serialized proving key payload.
+func encodeProvingKeyFile(payload []byte) []byte {
+	data := make([]byte, 0, provingKeyHeaderLen+len(payload))
+	data = append(data, provingKeyMagic...)
+	data = binary.BigEndian.AppendUint32(data, provingKeyVersionTag())
+	data = binary.BigEndian.AppendUint32(data, provingKeyKParam())
+	data = append(data, payload...)
+	return data
+}
+
+// decodeProvingKeyFile validates the header of a proving-key cache file
+// against the version and k-parameter this binary was built for, and
+// returns the remaining serialized payload.
+func decodeProvingKeyFile(data []byte) ([]byte, error) {
+	if len(data) < provingKeyHeaderLen {
+		return nil, fmt.Errorf("truncated proving key file")
+	}
+	if string(data[:len(provingKeyMagic)]) != provingKeyMagic {
+		return nil, fmt.Errorf("not a t2z proving key file")
+	}
+	offset := len(provingKeyMagic)
+	version := binary.BigEndian.Uint32(data[offset : offset+4])
+	offset += 4
+	kParam := binary.BigEndian.Uint32(data[offset : offset+4])
+	offset += 4
+
+	if wantVersion := provingKeyVersionTag(); version != wantVersion {
+		return nil, fmt.Errorf("proving key version %d does not match expected %d", version, wantVersion)
+	}
+	if wantKParam := provingKeyKParam(); kParam != wantKParam {
+		return nil, fmt.Errorf("proving key k-parameter %d does not match expected %d", kParam, wantKParam)
+	}
+	return data[offset:], nil
+}
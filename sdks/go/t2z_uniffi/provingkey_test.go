@@ -0,0 +1,66 @@
+package t2z_uniffi
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestDecodeProvingKeyFileRejectsBadMagic(t *testing.T) {
+	data := make([]byte, provingKeyHeaderLen+4)
+	if _, err := decodeProvingKeyFile(data); err == nil {
+		t.Error("expected error for bad magic, got nil")
+	}
+}
+
+func TestDecodeProvingKeyFileRejectsTruncated(t *testing.T) {
+	data := []byte(provingKeyMagic)
+	if _, err := decodeProvingKeyFile(data); err == nil {
+		t.Error("expected error for truncated header, got nil")
+	}
+}
+
+func TestEncodeDecodeProvingKeyFileHeaderRoundTrip(t *testing.T) {
+	payload := []byte{1, 2, 3, 4}
+	data := encodeProvingKeyFile(payload)
+	if len(data) != provingKeyHeaderLen+len(payload) {
+		t.Fatalf("encoded length = %d, want %d", len(data), provingKeyHeaderLen+len(payload))
+	}
+	if string(data[:len(provingKeyMagic)]) != provingKeyMagic {
+		t.Errorf("magic not written at start of file")
+	}
+	// provingKeyVersionTag and provingKeyKParam are plain Go getters (see
+	// the NOTE in t2z_uniffi.go), so the freshly encoded header passes
+	// decodeProvingKeyFile's version/k-param checks.
+	got, err := decodeProvingKeyFile(data)
+	if err != nil {
+		t.Fatalf("decodeProvingKeyFile: %v", err)
+	}
+	if !bytes.Equal(got, payload) {
+		t.Errorf("decoded payload = %v, want %v", got, payload)
+	}
+}
+
+// TestEnsureProvingKeyMissingCacheAttemptsRealBuild exercises the full
+// LoadProvingKey -> PrebuildProvingKey -> SaveProvingKey path EnsureProvingKey
+// takes when path doesn't exist yet. This requires the real vendored Rust
+// library to run PrebuildProvingKey (same requirement as every other cgo
+// call in this package), so it can't reach a success return in an
+// environment without that library linked. It pins the one failure mode
+// that's reachable either way: serializeProvingKeyBytes has no
+// implementation in the vendored crate yet (see the NOTE in t2z_uniffi.go),
+// so SaveProvingKey - and therefore EnsureProvingKey - must fail with
+// errSerializeProvingKeyNotImplemented even once PrebuildProvingKey itself
+// succeeds.
+func TestEnsureProvingKeyMissingCacheAttemptsRealBuild(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "proving-key.cache")
+
+	err := EnsureProvingKey(path)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !errors.Is(err, errSerializeProvingKeyNotImplemented) {
+		t.Errorf("expected error wrapping errSerializeProvingKeyNotImplemented, got %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+//go:build cbor
+
+package t2z_uniffi
+
+import (
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+func TestExpectedTxOutCBORRoundTrip(t *testing.T) {
+	want := UniffiExpectedTxOut{Address: "t1change", Amount: 100000}
+
+	data, err := want.MarshalCBOR()
+	if err != nil {
+		t.Fatalf("MarshalCBOR: %v", err)
+	}
+
+	var got UniffiExpectedTxOut
+	if err := got.UnmarshalCBOR(data); err != nil {
+		t.Fatalf("UnmarshalCBOR: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestExpectedTxOutCBORRejectsUnknownFields(t *testing.T) {
+	encoded, err := cbor.Marshal(map[string]interface{}{
+		"address": "t1x",
+		"amount":  1,
+		"extra":   true,
+	})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var out UniffiExpectedTxOut
+	if err := out.UnmarshalCBOR(encoded); err == nil {
+		t.Error("expected error for unknown field, got nil")
+	}
+}
+
+func TestPcztCBOREnvelopeRejectsWrongVersion(t *testing.T) {
+	encoded, err := cbor.Marshal(pcztCBOREnvelope{Version: 2, Format: pcztJSONFormat, Data: []byte{0x00}})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var pczt UniffiPczt
+	if err := pczt.UnmarshalCBOR(encoded); err == nil {
+		t.Error("expected error for unsupported version, got nil")
+	}
+}
+
+func TestPcztCBOREnvelopeRejectsWrongFormat(t *testing.T) {
+	encoded, err := cbor.Marshal(pcztCBOREnvelope{Version: pcztJSONVersion, Format: "pczt-v2", Data: []byte{0x00}})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var pczt UniffiPczt
+	if err := pczt.UnmarshalCBOR(encoded); err == nil {
+		t.Error("expected error for unsupported format, got nil")
+	}
+}
+
+func TestPcztCBOREnvelopeRejectsUnknownFields(t *testing.T) {
+	encoded, err := cbor.Marshal(map[string]interface{}{
+		"version": pcztJSONVersion,
+		"format":  pcztJSONFormat,
+		"data":    []byte{0x00},
+		"extra":   true,
+	})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+
+	var pczt UniffiPczt
+	if err := pczt.UnmarshalCBOR(encoded); err == nil {
+		t.Error("expected error for unknown field, got nil")
+	}
+}
@@ -0,0 +1,92 @@
+package t2z_uniffi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+func TestExpectedTxOutJSONRoundTrip(t *testing.T) {
+	want := UniffiExpectedTxOut{Address: "t1change", Amount: 100000}
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"address":"t1change"`) {
+		t.Errorf("expected address field in JSON, got %s", data)
+	}
+
+	var got UniffiExpectedTxOut
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestExpectedTxOutJSONRejectsUnknownFields(t *testing.T) {
+	var out UniffiExpectedTxOut
+	err := json.Unmarshal([]byte(`{"address":"t1x","amount":1,"extra":true}`), &out)
+	if err == nil {
+		t.Error("expected error for unknown field, got nil")
+	}
+}
+
+func TestPcztJSONEnvelopeRejectsWrongVersion(t *testing.T) {
+	var pczt UniffiPczt
+	err := pczt.UnmarshalJSON([]byte(`{"version":2,"format":"pczt-v1","data":"00"}`))
+	if err == nil {
+		t.Error("expected error for unsupported version, got nil")
+	}
+}
+
+func TestPcztJSONEnvelopeRejectsWrongFormat(t *testing.T) {
+	var pczt UniffiPczt
+	err := pczt.UnmarshalJSON([]byte(`{"version":1,"format":"pczt-v2","data":"00"}`))
+	if err == nil {
+		t.Error("expected error for unsupported format, got nil")
+	}
+}
+
+func TestPcztJSONEnvelopeRejectsUnknownFields(t *testing.T) {
+	var pczt UniffiPczt
+	err := pczt.UnmarshalJSON([]byte(`{"version":1,"format":"pczt-v1","data":"00","extra":true}`))
+	if err == nil {
+		t.Error("expected error for unknown field, got nil")
+	}
+}
+
+// TestUniffiPcztAdoptTransfersOwnership covers the success path
+// UnmarshalJSON/UnmarshalCBOR rely on: adopt must move decoded's
+// pointer/clone/free functions into p rather than copying the FfiObject
+// value, which would leave decoded's finalizer (and refcount) behind
+// pointing at the same Rust pointer as p's independent copy.
+func TestUniffiPcztAdoptTransfersOwnership(t *testing.T) {
+	fakePointer := unsafe.Pointer(new(byte))
+	decoded := FfiConverterUniffiPcztINSTANCE.Lift(fakePointer)
+
+	var p UniffiPczt
+	p.adopt(decoded)
+
+	if p.ffiObject.pointer != decoded.ffiObject.pointer {
+		t.Errorf("pointer not transferred: got %v, want %v", p.ffiObject.pointer, decoded.ffiObject.pointer)
+	}
+	if reflect.ValueOf(p.ffiObject.cloneFunction).Pointer() != reflect.ValueOf(decoded.ffiObject.cloneFunction).Pointer() {
+		t.Error("cloneFunction not transferred")
+	}
+	if reflect.ValueOf(p.ffiObject.freeFunction).Pointer() != reflect.ValueOf(decoded.ffiObject.freeFunction).Pointer() {
+		t.Error("freeFunction not transferred")
+	}
+	// p must own its own fresh, untouched refcount rather than a copy of
+	// decoded's atomics - it was never incremented/destroyed.
+	if p.ffiObject.callCounter.Load() != 0 {
+		t.Error("p should start with an untouched call counter")
+	}
+	if p.ffiObject.destroyed.Load() {
+		t.Error("p should not start out destroyed")
+	}
+}
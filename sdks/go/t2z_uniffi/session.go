@@ -0,0 +1,257 @@
+package t2z_uniffi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Sighash is the digest a signer must sign for a specific transparent
+// input, as returned by GetSighash. Wrapping the raw hex string in a type
+// lets signers be pinned to a specific tx digest instead of accidentally
+// comparing against the wrong input's hash.
+type Sighash struct {
+	hex string
+}
+
+// Hex returns the sighash as a hex string.
+func (s Sighash) Hex() string { return s.hex }
+
+// Equal reports whether two sighashes are the same digest.
+func (s Sighash) Equal(other Sighash) bool { return s.hex == other.hex }
+
+// PcztSession models the multi-party PCZT workflow (proposer -> signer(s) ->
+// combiner -> finalizer) explicitly, instead of requiring callers to hold
+// every signer's PCZT in memory and call CombinePczts themselves.
+type PcztSession struct {
+	mu   sync.Mutex
+	pczt *UniffiPczt
+	log  *contributionLog
+}
+
+// NewPcztSession starts a coordination session from a freshly-proposed PCZT.
+func NewPcztSession(proposed *UniffiPczt) *PcztSession {
+	return &PcztSession{pczt: proposed}
+}
+
+// OpenPcztSession resumes a session from its on-disk contribution log,
+// replaying each recorded delta through CombinePczts so a coordinator
+// process can crash-recover mid-signing-ceremony. logPath is created if it
+// doesn't exist yet.
+func OpenPcztSession(proposed *UniffiPczt, logPath string) (*PcztSession, error) {
+	log, err := openContributionLog(logPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening contribution log: %w", err)
+	}
+	session := &PcztSession{pczt: proposed, log: log}
+
+	entries, err := log.readAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading contribution log: %w", err)
+	}
+	for _, entry := range entries {
+		contrib, err := UniffiPcztFromBytes(entry.delta)
+		if err != nil {
+			return nil, fmt.Errorf("replaying contribution from %s: %w", entry.peerID, err)
+		}
+		if err := session.combineLocked(contrib); err != nil {
+			return nil, fmt.Errorf("replaying contribution from %s: %w", entry.peerID, err)
+		}
+	}
+	return session, nil
+}
+
+// AddSignerContribution merges a signer's partial PCZT into the session,
+// then - only once that combine succeeds - records the contribution to the
+// on-disk log (if one is attached) so a crash recovers the same state on
+// restart. Logging only ever needs to cover committed state: if the process
+// crashes before the combine lands in memory, the in-memory pczt is lost
+// either way, so there's nothing to gain from logging first, and logging
+// first would instead let a rejected (not crashed) contribution - bad or
+// duplicate data, wrong session, a transient error - leave a permanently
+// unreplayable entry that bricks OpenPcztSession on every future restart.
+// inputIndices names the transparent inputs contrib signed, so the caller
+// must track which inputs it handed to a given signer; there's no way to
+// recover that from the PCZT itself (InspectPczt has no backing Rust
+// export - see its doc comment).
+func (s *PcztSession) AddSignerContribution(peerID string, inputIndices []uint32, contrib *UniffiPczt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sighash, err := s.contributionSighash(inputIndices)
+	if err != nil {
+		return fmt.Errorf("computing sighash for contribution from %s: %w", peerID, err)
+	}
+
+	if err := s.combineLocked(contrib); err != nil {
+		return err
+	}
+
+	if s.log != nil {
+		if err := s.log.append(peerID, sighash, contrib.ToBytes()); err != nil {
+			return fmt.Errorf("recording contribution from %s: %w", peerID, err)
+		}
+	}
+	return nil
+}
+
+// contributionSighash reports the sighash(es) for inputIndices, read from
+// the session's current pre-combine PCZT so the log pins each contribution
+// to the exact digest it should have signed. Joined by commas on the rare
+// case one contribution signs more than one input.
+func (s *PcztSession) contributionSighash(inputIndices []uint32) (string, error) {
+	var hashes []string
+	for _, idx := range inputIndices {
+		hash, err := GetSighash(s.pczt, idx)
+		if err != nil {
+			return "", err
+		}
+		hashes = append(hashes, hash)
+	}
+	return strings.Join(hashes, ","), nil
+}
+
+func (s *PcztSession) combineLocked(contrib *UniffiPczt) error {
+	combined, err := CombinePczts([]*UniffiPczt{s.pczt, contrib})
+	if err != nil {
+		return err
+	}
+	s.pczt = combined
+	return nil
+}
+
+// Sighash returns the sighash for a transparent input in the current
+// session PCZT, for pinning a signer to the exact digest it should sign.
+func (s *PcztSession) Sighash(inputIndex uint32) (Sighash, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hex, err := GetSighash(s.pczt, inputIndex)
+	if err != nil {
+		return Sighash{}, err
+	}
+	return Sighash{hex: hex}, nil
+}
+
+// Verify checks the session PCZT against the original transaction request
+// and expected change outputs before any party signs it.
+func (s *PcztSession) Verify(transactionRequest UniffiTransactionRequest, expectedChange []UniffiExpectedTxOut) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return VerifyBeforeSigning(s.pczt, transactionRequest, expectedChange)
+}
+
+// Finalize finalizes the session PCZT and extracts the raw transaction
+// bytes, once every party has contributed.
+func (s *PcztSession) Finalize() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return FinalizeAndExtract(s.pczt)
+}
+
+// Pczt returns the session's current combined PCZT.
+func (s *PcztSession) Pczt() *UniffiPczt {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pczt
+}
+
+// contributionLog is a small append-only log of signer contributions:
+// each entry is (peer ID, sighash, serialized delta), written as
+// length-prefixed fields so a coordinator process can replay it on restart.
+type contributionLog struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+type contributionLogEntry struct {
+	peerID  string
+	sighash string
+	delta   []byte
+}
+
+func openContributionLog(path string) (*contributionLog, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &contributionLog{file: f}, nil
+}
+
+func (l *contributionLog) append(peerID, sighash string, delta []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var buf []byte
+	buf = appendLengthPrefixed(buf, []byte(peerID))
+	buf = appendLengthPrefixed(buf, []byte(sighash))
+	buf = appendLengthPrefixed(buf, delta)
+
+	if _, err := l.file.Write(buf); err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+func (l *contributionLog) readAll() ([]contributionLogEntry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	var entries []contributionLogEntry
+	for {
+		peerID, err := readLengthPrefixed(l.file)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		sighash, err := readLengthPrefixed(l.file)
+		if err != nil {
+			return nil, fmt.Errorf("truncated contribution log entry: %w", err)
+		}
+		delta, err := readLengthPrefixed(l.file)
+		if err != nil {
+			return nil, fmt.Errorf("truncated contribution log entry: %w", err)
+		}
+		entries = append(entries, contributionLogEntry{
+			peerID:  string(peerID),
+			sighash: string(sighash),
+			delta:   delta,
+		})
+	}
+	if _, err := l.file.Seek(0, io.SeekEnd); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (l *contributionLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+func appendLengthPrefixed(buf, field []byte) []byte {
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(field)))
+	buf = append(buf, lenBuf[:]...)
+	return append(buf, field...)
+}
+
+func readLengthPrefixed(r io.Reader) ([]byte, error) {
+	var lenBuf [4]byte
+	if _, err := io.ReadFull(r, lenBuf[:]); err != nil {
+		return nil, err
+	}
+	field := make([]byte, binary.BigEndian.Uint32(lenBuf[:]))
+	if _, err := io.ReadFull(r, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
@@ -0,0 +1,83 @@
+package t2z_uniffi
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+func TestSighashEqual(t *testing.T) {
+	a := Sighash{hex: "abcd"}
+	b := Sighash{hex: "abcd"}
+	c := Sighash{hex: "ef01"}
+
+	if !a.Equal(b) {
+		t.Error("expected equal sighashes to compare equal")
+	}
+	if a.Equal(c) {
+		t.Error("expected different sighashes to compare unequal")
+	}
+	if a.Hex() != "abcd" {
+		t.Errorf("Hex() = %q, want %q", a.Hex(), "abcd")
+	}
+}
+
+func TestContributionLogAppendAndReadAll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contributions.log")
+
+	log, err := openContributionLog(path)
+	if err != nil {
+		t.Fatalf("openContributionLog: %v", err)
+	}
+	defer log.Close()
+
+	if err := log.append("signer-1", "sighash-1", []byte{1, 2, 3}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := log.append("signer-2", "sighash-2", []byte{4, 5, 6, 7}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	entries, err := log.readAll()
+	if err != nil {
+		t.Fatalf("readAll: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].peerID != "signer-1" || !bytes.Equal(entries[0].delta, []byte{1, 2, 3}) {
+		t.Errorf("unexpected first entry: %+v", entries[0])
+	}
+	if entries[1].peerID != "signer-2" || !bytes.Equal(entries[1].delta, []byte{4, 5, 6, 7}) {
+		t.Errorf("unexpected second entry: %+v", entries[1])
+	}
+}
+
+func TestContributionLogReopenReplaysEntries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "contributions.log")
+
+	log1, err := openContributionLog(path)
+	if err != nil {
+		t.Fatalf("openContributionLog: %v", err)
+	}
+	if err := log1.append("signer-1", "sighash-1", []byte{9}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if err := log1.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	log2, err := openContributionLog(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer log2.Close()
+
+	entries, err := log2.readAll()
+	if err != nil {
+		t.Fatalf("readAll after reopen: %v", err)
+	}
+	if len(entries) != 1 || entries[0].peerID != "signer-1" {
+		t.Errorf("expected recovered entry from signer-1, got %+v", entries)
+	}
+}
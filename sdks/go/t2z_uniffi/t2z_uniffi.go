@@ -8,7 +8,9 @@ import "C"
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -17,6 +19,20 @@ import (
 	"unsafe"
 )
 
+// The functions below bind to Rust exports that have no implementation in
+// the t2z_uniffi crate as vendored in this repo. Each one returns its
+// sentinel error rather than declare a cgo call against a symbol that
+// doesn't exist; see the NOTE on each function for the export it's blocked
+// on.
+var (
+	errInspectPcztNotImplemented                = errors.New("t2z_uniffi: InspectPczt is not yet implemented (blocked on the inspect_pczt rust export)")
+	errSerializeProvingKeyNotImplemented        = errors.New("t2z_uniffi: serializeProvingKeyBytes is not yet implemented (blocked on the serialize_proving_key rust export)")
+	errDeserializeProvingKeyNotImplemented      = errors.New("t2z_uniffi: deserializeProvingKeyBytes is not yet implemented (blocked on the deserialize_proving_key rust export)")
+	errProposeShieldedTransactionNotImplemented = errors.New("t2z_uniffi: ProposeShieldedTransaction is not yet implemented (blocked on the propose_shielded_transaction rust export)")
+	errSignOrchardSpendNotImplemented           = errors.New("t2z_uniffi: SignOrchardSpend is not yet implemented (blocked on the sign_orchard_spend rust export)")
+	errSignSaplingSpendNotImplemented           = errors.New("t2z_uniffi: SignSaplingSpend is not yet implemented (blocked on the sign_sapling_spend rust export)")
+)
+
 // This is needed, because as of go 1.24
 // type RustBuffer C.RustBuffer cannot have methods,
 // RustBuffer is treated as non-local type
@@ -113,11 +129,9 @@ func LowerIntoRustBuffer[GoType any](bufWriter BufWriter[GoType], value GoType)
 	var buffer bytes.Buffer
 	bufWriter.Write(&buffer, value)
 
-	bytes, err := io.ReadAll(&buffer)
-	if err != nil {
-		panic(fmt.Errorf("reading written data: %w", err))
-	}
-	return bytesToRustBuffer(bytes)
+	// buffer.Bytes() is already the fully written backing array; io.ReadAll
+	// on top of it used to allocate and copy it a second time for no reason.
+	return bytesToRustBuffer(buffer.Bytes())
 }
 
 func LiftFromRustBuffer[GoType any](bufReader BufReader[GoType], rbuf RustBufferI) GoType {
@@ -194,142 +208,102 @@ type NativeError interface {
 }
 
 func writeInt8(writer io.Writer, value int8) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+	fastWrite(writer, 1, func(b []byte) { b[0] = byte(value) })
 }
 
 func writeUint8(writer io.Writer, value uint8) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+	fastWrite(writer, 1, func(b []byte) { b[0] = value })
 }
 
 func writeInt16(writer io.Writer, value int16) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+	fastWrite(writer, 2, func(b []byte) { binary.BigEndian.PutUint16(b, uint16(value)) })
 }
 
 func writeUint16(writer io.Writer, value uint16) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+	fastWrite(writer, 2, func(b []byte) { binary.BigEndian.PutUint16(b, value) })
 }
 
 func writeInt32(writer io.Writer, value int32) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+	fastWrite(writer, 4, func(b []byte) { binary.BigEndian.PutUint32(b, uint32(value)) })
 }
 
 func writeUint32(writer io.Writer, value uint32) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+	fastWrite(writer, 4, func(b []byte) { binary.BigEndian.PutUint32(b, value) })
 }
 
 func writeInt64(writer io.Writer, value int64) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+	fastWrite(writer, 8, func(b []byte) { binary.BigEndian.PutUint64(b, uint64(value)) })
 }
 
 func writeUint64(writer io.Writer, value uint64) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+	fastWrite(writer, 8, func(b []byte) { binary.BigEndian.PutUint64(b, value) })
 }
 
 func writeFloat32(writer io.Writer, value float32) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+	fastWrite(writer, 4, func(b []byte) { binary.BigEndian.PutUint32(b, math.Float32bits(value)) })
 }
 
 func writeFloat64(writer io.Writer, value float64) {
-	if err := binary.Write(writer, binary.BigEndian, value); err != nil {
-		panic(err)
-	}
+	fastWrite(writer, 8, func(b []byte) { binary.BigEndian.PutUint64(b, math.Float64bits(value)) })
 }
 
 func readInt8(reader io.Reader) int8 {
 	var result int8
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
+	fastRead(reader, 1, func(b []byte) { result = int8(b[0]) })
 	return result
 }
 
 func readUint8(reader io.Reader) uint8 {
 	var result uint8
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
+	fastRead(reader, 1, func(b []byte) { result = b[0] })
 	return result
 }
 
 func readInt16(reader io.Reader) int16 {
 	var result int16
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
+	fastRead(reader, 2, func(b []byte) { result = int16(binary.BigEndian.Uint16(b)) })
 	return result
 }
 
 func readUint16(reader io.Reader) uint16 {
 	var result uint16
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
+	fastRead(reader, 2, func(b []byte) { result = binary.BigEndian.Uint16(b) })
 	return result
 }
 
 func readInt32(reader io.Reader) int32 {
 	var result int32
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
+	fastRead(reader, 4, func(b []byte) { result = int32(binary.BigEndian.Uint32(b)) })
 	return result
 }
 
 func readUint32(reader io.Reader) uint32 {
 	var result uint32
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
+	fastRead(reader, 4, func(b []byte) { result = binary.BigEndian.Uint32(b) })
 	return result
 }
 
 func readInt64(reader io.Reader) int64 {
 	var result int64
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
+	fastRead(reader, 8, func(b []byte) { result = int64(binary.BigEndian.Uint64(b)) })
 	return result
 }
 
 func readUint64(reader io.Reader) uint64 {
 	var result uint64
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
+	fastRead(reader, 8, func(b []byte) { result = binary.BigEndian.Uint64(b) })
 	return result
 }
 
 func readFloat32(reader io.Reader) float32 {
 	var result float32
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
+	fastRead(reader, 4, func(b []byte) { result = math.Float32frombits(binary.BigEndian.Uint32(b)) })
 	return result
 }
 
 func readFloat64(reader io.Reader) float64 {
 	var result float64
-	if err := binary.Read(reader, binary.BigEndian, &result); err != nil {
-		panic(err)
-	}
+	fastRead(reader, 8, func(b []byte) { result = math.Float64frombits(binary.BigEndian.Uint64(b)) })
 	return result
 }
 
@@ -367,6 +341,8 @@ func uniffiCheckChecksums() {
 			panic("t2z_uniffi: uniffi_t2z_uniffi_checksum_func_combine_pczts: UniFFI API checksum mismatch")
 		}
 	}
+	// NOTE: estimate_fee has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
 	{
 		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
 			return C.uniffi_t2z_uniffi_checksum_func_finalize_and_extract()
@@ -394,6 +370,8 @@ func uniffiCheckChecksums() {
 			panic("t2z_uniffi: uniffi_t2z_uniffi_checksum_func_get_sighash: UniFFI API checksum mismatch")
 		}
 	}
+	// NOTE: inspect_pczt has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
 	{
 		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
 			return C.uniffi_t2z_uniffi_checksum_func_is_proving_key_ready()
@@ -403,6 +381,14 @@ func uniffiCheckChecksums() {
 			panic("t2z_uniffi: uniffi_t2z_uniffi_checksum_func_is_proving_key_ready: UniFFI API checksum mismatch")
 		}
 	}
+	// NOTE: serialize_proving_key has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
+	// NOTE: deserialize_proving_key has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
+	// NOTE: proving_key_version_tag has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
+	// NOTE: proving_key_k_param has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
 	{
 		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
 			return C.uniffi_t2z_uniffi_checksum_func_prebuild_proving_key()
@@ -412,6 +398,16 @@ func uniffiCheckChecksums() {
 			panic("t2z_uniffi: uniffi_t2z_uniffi_checksum_func_prebuild_proving_key: UniFFI API checksum mismatch")
 		}
 	}
+	// NOTE: prebuild_proving_key_with_progress has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
+	// NOTE: prove_transaction_with_progress has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
+	// NOTE: new_cancel_handle has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
+	// NOTE: unifficancelhandle_cancel has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
+	// NOTE: propose_shielded_transaction has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
 	{
 		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
 			return C.uniffi_t2z_uniffi_checksum_func_propose_transaction()
@@ -430,6 +426,12 @@ func uniffiCheckChecksums() {
 			panic("t2z_uniffi: uniffi_t2z_uniffi_checksum_func_prove_transaction: UniFFI API checksum mismatch")
 		}
 	}
+	// NOTE: select_inputs has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
+	// NOTE: sign_orchard_spend has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
+	// NOTE: sign_sapling_spend has no checksum export in the t2z_uniffi crate
+	// vendored in this repo - see the matching NOTE comment below.
 	{
 		checksum := rustCall(func(_uniffiStatus *C.RustCallStatus) C.uint16_t {
 			return C.uniffi_t2z_uniffi_checksum_func_sign_transparent_input()
@@ -519,6 +521,49 @@ type FfiDestroyerUint32 struct{}
 
 func (FfiDestroyerUint32) Destroy(_ uint32) {}
 
+type FfiConverterSequenceUint32 struct{}
+
+var FfiConverterSequenceUint32INSTANCE = FfiConverterSequenceUint32{}
+
+func (c FfiConverterSequenceUint32) Lift(rb RustBufferI) []uint32 {
+	return LiftFromRustBuffer[[]uint32](c, rb)
+}
+
+func (c FfiConverterSequenceUint32) Read(reader io.Reader) []uint32 {
+	length := readInt32(reader)
+	if length == 0 {
+		return nil
+	}
+	result := make([]uint32, 0, length)
+	for i := int32(0); i < length; i++ {
+		result = append(result, FfiConverterUint32INSTANCE.Read(reader))
+	}
+	return result
+}
+
+func (c FfiConverterSequenceUint32) Lower(value []uint32) C.RustBuffer {
+	return LowerIntoRustBuffer[[]uint32](c, value)
+}
+
+func (c FfiConverterSequenceUint32) Write(writer io.Writer, value []uint32) {
+	if len(value) > math.MaxInt32 {
+		panic("[]uint32 is too large to fit into Int32")
+	}
+
+	writeInt32(writer, int32(len(value)))
+	for _, item := range value {
+		FfiConverterUint32INSTANCE.Write(writer, item)
+	}
+}
+
+type FfiDestroyerSequenceUint32 struct{}
+
+func (FfiDestroyerSequenceUint32) Destroy(sequence []uint32) {
+	for _, value := range sequence {
+		FfiDestroyerUint32{}.Destroy(value)
+	}
+}
+
 type FfiConverterUint64 struct{}
 
 var FfiConverterUint64INSTANCE = FfiConverterUint64{}
@@ -591,12 +636,12 @@ func (FfiConverterString) Lift(rb RustBufferI) string {
 func (FfiConverterString) Read(reader io.Reader) string {
 	length := readInt32(reader)
 	buffer := make([]byte, length)
-	read_length, err := reader.Read(buffer)
-	if err != nil && err != io.EOF {
-		panic(err)
-	}
-	if read_length != int(length) {
-		panic(fmt.Errorf("bad read length when reading string, expected %d, read %d", length, read_length))
+	// io.Reader is explicitly allowed to return fewer bytes than requested
+	// even when more data is available; reader.Read(buffer) once and
+	// checking the count against length is wrong for anything but a
+	// reader that happens to always fill the buffer (e.g. bytes.Reader).
+	if _, err := io.ReadFull(reader, buffer); err != nil {
+		panic(fmt.Errorf("reading string: %w", err))
 	}
 	return string(buffer)
 }
@@ -654,12 +699,8 @@ func (c FfiConverterBytes) Lift(rb RustBufferI) []byte {
 func (c FfiConverterBytes) Read(reader io.Reader) []byte {
 	length := readInt32(reader)
 	buffer := make([]byte, length)
-	read_length, err := reader.Read(buffer)
-	if err != nil && err != io.EOF {
-		panic(err)
-	}
-	if read_length != int(length) {
-		panic(fmt.Errorf("bad read length when reading []byte, expected %d, read %d", length, read_length))
+	if _, err := io.ReadFull(reader, buffer); err != nil {
+		panic(fmt.Errorf("reading []byte: %w", err))
 	}
 	return buffer
 }
@@ -839,6 +880,48 @@ func (_ FfiDestroyerUniffiPczt) Destroy(value *UniffiPczt) {
 	value.Destroy()
 }
 
+// Strategy used by SelectInputs to choose which available UTXOs to spend
+type UniffiCoinSelectionStrategy uint
+
+const (
+	// Spend the largest-value UTXOs first, minimizing the input count
+	UniffiCoinSelectionStrategyLargestFirst UniffiCoinSelectionStrategy = 1
+	// Spend the smallest-value UTXOs first, consolidating dust
+	UniffiCoinSelectionStrategySmallestFirst UniffiCoinSelectionStrategy = 2
+	// Search for a combination summing to exactly the target value, so no
+	// change is needed, falling back to LargestFirst if no such combination
+	// is found
+	UniffiCoinSelectionStrategyBranchAndBound UniffiCoinSelectionStrategy = 3
+	// Select UTXOs in random order, for privacy against input-count heuristics
+	UniffiCoinSelectionStrategyRandom UniffiCoinSelectionStrategy = 4
+)
+
+type FfiConverterUniffiCoinSelectionStrategy struct{}
+
+var FfiConverterUniffiCoinSelectionStrategyINSTANCE = FfiConverterUniffiCoinSelectionStrategy{}
+
+func (c FfiConverterUniffiCoinSelectionStrategy) Lift(rb RustBufferI) UniffiCoinSelectionStrategy {
+	return LiftFromRustBuffer[UniffiCoinSelectionStrategy](c, rb)
+}
+
+func (c FfiConverterUniffiCoinSelectionStrategy) Lower(value UniffiCoinSelectionStrategy) C.RustBuffer {
+	return LowerIntoRustBuffer[UniffiCoinSelectionStrategy](c, value)
+}
+
+func (c FfiConverterUniffiCoinSelectionStrategy) Read(reader io.Reader) UniffiCoinSelectionStrategy {
+	id := readInt32(reader)
+	return UniffiCoinSelectionStrategy(id)
+}
+
+func (c FfiConverterUniffiCoinSelectionStrategy) Write(writer io.Writer, value UniffiCoinSelectionStrategy) {
+	writeInt32(writer, int32(value))
+}
+
+type FfiDestroyerUniffiCoinSelectionStrategy struct{}
+
+func (_ FfiDestroyerUniffiCoinSelectionStrategy) Destroy(value UniffiCoinSelectionStrategy) {
+}
+
 // Expected transaction output for verification
 // Per spec: verify_before_signing takes expected_change: [TxOut]
 type UniffiExpectedTxOut struct {
@@ -883,6 +966,185 @@ func (_ FfiDestroyerUniffiExpectedTxOut) Destroy(value UniffiExpectedTxOut) {
 	value.Destroy()
 }
 
+// Fee and input-selection preview for a transaction request, as computed by
+// EstimateFee without building or proving a PCZT
+type UniffiFeeEstimate struct {
+	// ZIP 317 fee in zatoshis
+	FeeZatoshis uint64
+	// Indices into the inputsAvailable slice passed to EstimateFee that
+	// would be spent
+	SelectedInputIndices []uint32
+	// Change returned to changeAddress, in zatoshis (0 if none)
+	ChangeAmount uint64
+	// ZIP 317 logical action count the fee was computed from
+	LogicalActions uint32
+	// Whether covering this request requires an Orchard bundle, e.g. for
+	// a memo or a shielded change output
+	RequiresOrchardBundle bool
+}
+
+func (r *UniffiFeeEstimate) Destroy() {
+	FfiDestroyerUint64{}.Destroy(r.FeeZatoshis)
+	FfiDestroyerSequenceUint32{}.Destroy(r.SelectedInputIndices)
+	FfiDestroyerUint64{}.Destroy(r.ChangeAmount)
+	FfiDestroyerUint32{}.Destroy(r.LogicalActions)
+	FfiDestroyerBool{}.Destroy(r.RequiresOrchardBundle)
+}
+
+type FfiConverterUniffiFeeEstimate struct{}
+
+var FfiConverterUniffiFeeEstimateINSTANCE = FfiConverterUniffiFeeEstimate{}
+
+func (c FfiConverterUniffiFeeEstimate) Lift(rb RustBufferI) UniffiFeeEstimate {
+	return LiftFromRustBuffer[UniffiFeeEstimate](c, rb)
+}
+
+func (c FfiConverterUniffiFeeEstimate) Read(reader io.Reader) UniffiFeeEstimate {
+	return UniffiFeeEstimate{
+		FfiConverterUint64INSTANCE.Read(reader),
+		FfiConverterSequenceUint32INSTANCE.Read(reader),
+		FfiConverterUint64INSTANCE.Read(reader),
+		FfiConverterUint32INSTANCE.Read(reader),
+		FfiConverterBoolINSTANCE.Read(reader),
+	}
+}
+
+func (c FfiConverterUniffiFeeEstimate) Lower(value UniffiFeeEstimate) C.RustBuffer {
+	return LowerIntoRustBuffer[UniffiFeeEstimate](c, value)
+}
+
+func (c FfiConverterUniffiFeeEstimate) Write(writer io.Writer, value UniffiFeeEstimate) {
+	FfiConverterUint64INSTANCE.Write(writer, value.FeeZatoshis)
+	FfiConverterSequenceUint32INSTANCE.Write(writer, value.SelectedInputIndices)
+	FfiConverterUint64INSTANCE.Write(writer, value.ChangeAmount)
+	FfiConverterUint32INSTANCE.Write(writer, value.LogicalActions)
+	FfiConverterBoolINSTANCE.Write(writer, value.RequiresOrchardBundle)
+}
+
+type FfiDestroyerUniffiFeeEstimate struct{}
+
+func (_ FfiDestroyerUniffiFeeEstimate) Destroy(value UniffiFeeEstimate) {
+	value.Destroy()
+}
+
+// An Orchard note to spend, with the witness data needed to prove it's
+// unspent, for ProposeShieldedTransaction
+type UniffiOrchardSpend struct {
+	// Note commitment (32 bytes as hex string)
+	NoteCommitmentHex string
+	// Nullifier (32 bytes as hex string)
+	NullifierHex string
+	// Value in zatoshis
+	Value uint64
+	// Note's rho value (32 bytes as hex string)
+	RhoHex string
+	// Note's rseed value (32 bytes as hex string)
+	RseedHex string
+	// Merkle path to the note commitment, from a lightwalletd incremental
+	// witness (hex encoded)
+	MerklePathHex string
+	// Anchor the merkle path is rooted at (32 bytes as hex string)
+	AnchorHex string
+	// Incoming viewing key able to decrypt this note (hex encoded)
+	IvkHex string
+}
+
+func (r *UniffiOrchardSpend) Destroy() {
+	FfiDestroyerString{}.Destroy(r.NoteCommitmentHex)
+	FfiDestroyerString{}.Destroy(r.NullifierHex)
+	FfiDestroyerUint64{}.Destroy(r.Value)
+	FfiDestroyerString{}.Destroy(r.RhoHex)
+	FfiDestroyerString{}.Destroy(r.RseedHex)
+	FfiDestroyerString{}.Destroy(r.MerklePathHex)
+	FfiDestroyerString{}.Destroy(r.AnchorHex)
+	FfiDestroyerString{}.Destroy(r.IvkHex)
+}
+
+type FfiConverterUniffiOrchardSpend struct{}
+
+var FfiConverterUniffiOrchardSpendINSTANCE = FfiConverterUniffiOrchardSpend{}
+
+func (c FfiConverterUniffiOrchardSpend) Lift(rb RustBufferI) UniffiOrchardSpend {
+	return LiftFromRustBuffer[UniffiOrchardSpend](c, rb)
+}
+
+func (c FfiConverterUniffiOrchardSpend) Read(reader io.Reader) UniffiOrchardSpend {
+	return UniffiOrchardSpend{
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterUint64INSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+	}
+}
+
+func (c FfiConverterUniffiOrchardSpend) Lower(value UniffiOrchardSpend) C.RustBuffer {
+	return LowerIntoRustBuffer[UniffiOrchardSpend](c, value)
+}
+
+func (c FfiConverterUniffiOrchardSpend) Write(writer io.Writer, value UniffiOrchardSpend) {
+	FfiConverterStringINSTANCE.Write(writer, value.NoteCommitmentHex)
+	FfiConverterStringINSTANCE.Write(writer, value.NullifierHex)
+	FfiConverterUint64INSTANCE.Write(writer, value.Value)
+	FfiConverterStringINSTANCE.Write(writer, value.RhoHex)
+	FfiConverterStringINSTANCE.Write(writer, value.RseedHex)
+	FfiConverterStringINSTANCE.Write(writer, value.MerklePathHex)
+	FfiConverterStringINSTANCE.Write(writer, value.AnchorHex)
+	FfiConverterStringINSTANCE.Write(writer, value.IvkHex)
+}
+
+type FfiDestroyerUniffiOrchardSpend struct{}
+
+func (_ FfiDestroyerUniffiOrchardSpend) Destroy(value UniffiOrchardSpend) {
+	value.Destroy()
+}
+
+type FfiConverterSequenceUniffiOrchardSpend struct{}
+
+var FfiConverterSequenceUniffiOrchardSpendINSTANCE = FfiConverterSequenceUniffiOrchardSpend{}
+
+func (c FfiConverterSequenceUniffiOrchardSpend) Lift(rb RustBufferI) []UniffiOrchardSpend {
+	return LiftFromRustBuffer[[]UniffiOrchardSpend](c, rb)
+}
+
+func (c FfiConverterSequenceUniffiOrchardSpend) Read(reader io.Reader) []UniffiOrchardSpend {
+	length := readInt32(reader)
+	if length == 0 {
+		return nil
+	}
+	result := make([]UniffiOrchardSpend, 0, length)
+	for i := int32(0); i < length; i++ {
+		result = append(result, FfiConverterUniffiOrchardSpendINSTANCE.Read(reader))
+	}
+	return result
+}
+
+func (c FfiConverterSequenceUniffiOrchardSpend) Lower(value []UniffiOrchardSpend) C.RustBuffer {
+	return LowerIntoRustBuffer[[]UniffiOrchardSpend](c, value)
+}
+
+func (c FfiConverterSequenceUniffiOrchardSpend) Write(writer io.Writer, value []UniffiOrchardSpend) {
+	if len(value) > math.MaxInt32 {
+		panic("[]UniffiOrchardSpend is too large to fit into Int32")
+	}
+
+	writeInt32(writer, int32(len(value)))
+	for _, item := range value {
+		FfiConverterUniffiOrchardSpendINSTANCE.Write(writer, item)
+	}
+}
+
+type FfiDestroyerSequenceUniffiOrchardSpend struct{}
+
+func (FfiDestroyerSequenceUniffiOrchardSpend) Destroy(sequence []UniffiOrchardSpend) {
+	for _, value := range sequence {
+		FfiDestroyerUniffiOrchardSpend{}.Destroy(value)
+	}
+}
+
 type UniffiPayment struct {
 	// Address (transparent P2PKH/P2SH or unified with Orchard)
 	Address string
@@ -935,6 +1197,437 @@ func (_ FfiDestroyerUniffiPayment) Destroy(value UniffiPayment) {
 	value.Destroy()
 }
 
+// Signing status of a single transparent input, as reported by InspectPczt
+type UniffiPcztInputInfo struct {
+	// Index of this input within the transaction
+	Index uint32
+	// Previous transaction ID (32 bytes as hex string)
+	PrevoutTxid string
+	// Previous output index
+	PrevoutIndex uint32
+	// Value in zatoshis
+	Value uint64
+	// Script pubkey (hex encoded)
+	ScriptPubkey string
+	// Public key required to sign this input (33 bytes as hex string)
+	RequiredPubkey string
+	// Whether a signature has already been attached to this input
+	SignaturePresent bool
+	// SIGHASH flags this input will be (or was) signed with
+	SighashType uint32
+}
+
+func (r *UniffiPcztInputInfo) Destroy() {
+	FfiDestroyerUint32{}.Destroy(r.Index)
+	FfiDestroyerString{}.Destroy(r.PrevoutTxid)
+	FfiDestroyerUint32{}.Destroy(r.PrevoutIndex)
+	FfiDestroyerUint64{}.Destroy(r.Value)
+	FfiDestroyerString{}.Destroy(r.ScriptPubkey)
+	FfiDestroyerString{}.Destroy(r.RequiredPubkey)
+	FfiDestroyerBool{}.Destroy(r.SignaturePresent)
+	FfiDestroyerUint32{}.Destroy(r.SighashType)
+}
+
+type FfiConverterUniffiPcztInputInfo struct{}
+
+var FfiConverterUniffiPcztInputInfoINSTANCE = FfiConverterUniffiPcztInputInfo{}
+
+func (c FfiConverterUniffiPcztInputInfo) Lift(rb RustBufferI) UniffiPcztInputInfo {
+	return LiftFromRustBuffer[UniffiPcztInputInfo](c, rb)
+}
+
+func (c FfiConverterUniffiPcztInputInfo) Read(reader io.Reader) UniffiPcztInputInfo {
+	return UniffiPcztInputInfo{
+		FfiConverterUint32INSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterUint32INSTANCE.Read(reader),
+		FfiConverterUint64INSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterBoolINSTANCE.Read(reader),
+		FfiConverterUint32INSTANCE.Read(reader),
+	}
+}
+
+func (c FfiConverterUniffiPcztInputInfo) Lower(value UniffiPcztInputInfo) C.RustBuffer {
+	return LowerIntoRustBuffer[UniffiPcztInputInfo](c, value)
+}
+
+func (c FfiConverterUniffiPcztInputInfo) Write(writer io.Writer, value UniffiPcztInputInfo) {
+	FfiConverterUint32INSTANCE.Write(writer, value.Index)
+	FfiConverterStringINSTANCE.Write(writer, value.PrevoutTxid)
+	FfiConverterUint32INSTANCE.Write(writer, value.PrevoutIndex)
+	FfiConverterUint64INSTANCE.Write(writer, value.Value)
+	FfiConverterStringINSTANCE.Write(writer, value.ScriptPubkey)
+	FfiConverterStringINSTANCE.Write(writer, value.RequiredPubkey)
+	FfiConverterBoolINSTANCE.Write(writer, value.SignaturePresent)
+	FfiConverterUint32INSTANCE.Write(writer, value.SighashType)
+}
+
+type FfiDestroyerUniffiPcztInputInfo struct{}
+
+func (_ FfiDestroyerUniffiPcztInputInfo) Destroy(value UniffiPcztInputInfo) {
+	value.Destroy()
+}
+
+type FfiConverterSequenceUniffiPcztInputInfo struct{}
+
+var FfiConverterSequenceUniffiPcztInputInfoINSTANCE = FfiConverterSequenceUniffiPcztInputInfo{}
+
+func (c FfiConverterSequenceUniffiPcztInputInfo) Lift(rb RustBufferI) []UniffiPcztInputInfo {
+	return LiftFromRustBuffer[[]UniffiPcztInputInfo](c, rb)
+}
+
+func (c FfiConverterSequenceUniffiPcztInputInfo) Read(reader io.Reader) []UniffiPcztInputInfo {
+	length := readInt32(reader)
+	if length == 0 {
+		return nil
+	}
+	result := make([]UniffiPcztInputInfo, 0, length)
+	for i := int32(0); i < length; i++ {
+		result = append(result, FfiConverterUniffiPcztInputInfoINSTANCE.Read(reader))
+	}
+	return result
+}
+
+func (c FfiConverterSequenceUniffiPcztInputInfo) Lower(value []UniffiPcztInputInfo) C.RustBuffer {
+	return LowerIntoRustBuffer[[]UniffiPcztInputInfo](c, value)
+}
+
+func (c FfiConverterSequenceUniffiPcztInputInfo) Write(writer io.Writer, value []UniffiPcztInputInfo) {
+	if len(value) > math.MaxInt32 {
+		panic("[]UniffiPcztInputInfo is too large to fit into Int32")
+	}
+
+	writeInt32(writer, int32(len(value)))
+	for _, item := range value {
+		FfiConverterUniffiPcztInputInfoINSTANCE.Write(writer, item)
+	}
+}
+
+type FfiDestroyerSequenceUniffiPcztInputInfo struct{}
+
+func (FfiDestroyerSequenceUniffiPcztInputInfo) Destroy(sequence []UniffiPcztInputInfo) {
+	for _, value := range sequence {
+		FfiDestroyerUniffiPcztInputInfo{}.Destroy(value)
+	}
+}
+
+// A transparent output, as reported by InspectPczt
+type UniffiPcztOutputInfo struct {
+	// Address (transparent or Orchard unified address)
+	Address string
+	// Value in zatoshis
+	Value uint64
+}
+
+func (r *UniffiPcztOutputInfo) Destroy() {
+	FfiDestroyerString{}.Destroy(r.Address)
+	FfiDestroyerUint64{}.Destroy(r.Value)
+}
+
+type FfiConverterUniffiPcztOutputInfo struct{}
+
+var FfiConverterUniffiPcztOutputInfoINSTANCE = FfiConverterUniffiPcztOutputInfo{}
+
+func (c FfiConverterUniffiPcztOutputInfo) Lift(rb RustBufferI) UniffiPcztOutputInfo {
+	return LiftFromRustBuffer[UniffiPcztOutputInfo](c, rb)
+}
+
+func (c FfiConverterUniffiPcztOutputInfo) Read(reader io.Reader) UniffiPcztOutputInfo {
+	return UniffiPcztOutputInfo{
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterUint64INSTANCE.Read(reader),
+	}
+}
+
+func (c FfiConverterUniffiPcztOutputInfo) Lower(value UniffiPcztOutputInfo) C.RustBuffer {
+	return LowerIntoRustBuffer[UniffiPcztOutputInfo](c, value)
+}
+
+func (c FfiConverterUniffiPcztOutputInfo) Write(writer io.Writer, value UniffiPcztOutputInfo) {
+	FfiConverterStringINSTANCE.Write(writer, value.Address)
+	FfiConverterUint64INSTANCE.Write(writer, value.Value)
+}
+
+type FfiDestroyerUniffiPcztOutputInfo struct{}
+
+func (_ FfiDestroyerUniffiPcztOutputInfo) Destroy(value UniffiPcztOutputInfo) {
+	value.Destroy()
+}
+
+type FfiConverterSequenceUniffiPcztOutputInfo struct{}
+
+var FfiConverterSequenceUniffiPcztOutputInfoINSTANCE = FfiConverterSequenceUniffiPcztOutputInfo{}
+
+func (c FfiConverterSequenceUniffiPcztOutputInfo) Lift(rb RustBufferI) []UniffiPcztOutputInfo {
+	return LiftFromRustBuffer[[]UniffiPcztOutputInfo](c, rb)
+}
+
+func (c FfiConverterSequenceUniffiPcztOutputInfo) Read(reader io.Reader) []UniffiPcztOutputInfo {
+	length := readInt32(reader)
+	if length == 0 {
+		return nil
+	}
+	result := make([]UniffiPcztOutputInfo, 0, length)
+	for i := int32(0); i < length; i++ {
+		result = append(result, FfiConverterUniffiPcztOutputInfoINSTANCE.Read(reader))
+	}
+	return result
+}
+
+func (c FfiConverterSequenceUniffiPcztOutputInfo) Lower(value []UniffiPcztOutputInfo) C.RustBuffer {
+	return LowerIntoRustBuffer[[]UniffiPcztOutputInfo](c, value)
+}
+
+func (c FfiConverterSequenceUniffiPcztOutputInfo) Write(writer io.Writer, value []UniffiPcztOutputInfo) {
+	if len(value) > math.MaxInt32 {
+		panic("[]UniffiPcztOutputInfo is too large to fit into Int32")
+	}
+
+	writeInt32(writer, int32(len(value)))
+	for _, item := range value {
+		FfiConverterUniffiPcztOutputInfoINSTANCE.Write(writer, item)
+	}
+}
+
+type FfiDestroyerSequenceUniffiPcztOutputInfo struct{}
+
+func (FfiDestroyerSequenceUniffiPcztOutputInfo) Destroy(sequence []UniffiPcztOutputInfo) {
+	for _, value := range sequence {
+		FfiDestroyerUniffiPcztOutputInfo{}.Destroy(value)
+	}
+}
+
+// Snapshot of a PCZT's contents and progress through the propose / sign /
+// prove / finalize workflow, for rendering a confirmation screen or
+// deciding what to do next without guessing from prior state or parsing
+// FinalizeAndExtract errors.
+type UniffiPcztInfo struct {
+	// "mainnet" or "testnet"
+	Network string
+	// Transaction expiry height
+	ExpiryHeight uint32
+	// Per-input signing status, in transaction order
+	TransparentInputs []UniffiPcztInputInfo
+	// Transparent outputs, in transaction order
+	TransparentOutputs []UniffiPcztOutputInfo
+	// Number of Orchard actions in the transaction
+	OrchardActionCount uint32
+	// Whether Orchard zero-knowledge proofs have been attached
+	OrchardProofsAttached bool
+	// Sum of transparent and Orchard input values, in zatoshis
+	TotalInputValue uint64
+	// Sum of transparent and Orchard output values, in zatoshis
+	TotalOutputValue uint64
+	// TotalInputValue minus TotalOutputValue
+	FeeZatoshis uint64
+	// The Creator role has run (inputs/outputs are fixed)
+	Created bool
+	// The IO Finalizer role has run (no further inputs/outputs can be added)
+	IoFinalized bool
+	// The Updater role has run (e.g. sighash data was added)
+	Updated bool
+	// The Prover role has run (Orchard proofs are attached)
+	Proven bool
+	// Every input that requires a signature has one
+	Signed bool
+	// The Combiner/Finalizer has produced a transaction ready for extraction
+	Finalized bool
+}
+
+func (r *UniffiPcztInfo) Destroy() {
+	FfiDestroyerString{}.Destroy(r.Network)
+	FfiDestroyerUint32{}.Destroy(r.ExpiryHeight)
+	FfiDestroyerSequenceUniffiPcztInputInfo{}.Destroy(r.TransparentInputs)
+	FfiDestroyerSequenceUniffiPcztOutputInfo{}.Destroy(r.TransparentOutputs)
+	FfiDestroyerUint32{}.Destroy(r.OrchardActionCount)
+	FfiDestroyerBool{}.Destroy(r.OrchardProofsAttached)
+	FfiDestroyerUint64{}.Destroy(r.TotalInputValue)
+	FfiDestroyerUint64{}.Destroy(r.TotalOutputValue)
+	FfiDestroyerUint64{}.Destroy(r.FeeZatoshis)
+	FfiDestroyerBool{}.Destroy(r.Created)
+	FfiDestroyerBool{}.Destroy(r.IoFinalized)
+	FfiDestroyerBool{}.Destroy(r.Updated)
+	FfiDestroyerBool{}.Destroy(r.Proven)
+	FfiDestroyerBool{}.Destroy(r.Signed)
+	FfiDestroyerBool{}.Destroy(r.Finalized)
+}
+
+type FfiConverterUniffiPcztInfo struct{}
+
+var FfiConverterUniffiPcztInfoINSTANCE = FfiConverterUniffiPcztInfo{}
+
+func (c FfiConverterUniffiPcztInfo) Lift(rb RustBufferI) UniffiPcztInfo {
+	return LiftFromRustBuffer[UniffiPcztInfo](c, rb)
+}
+
+func (c FfiConverterUniffiPcztInfo) Read(reader io.Reader) UniffiPcztInfo {
+	return UniffiPcztInfo{
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterUint32INSTANCE.Read(reader),
+		FfiConverterSequenceUniffiPcztInputInfoINSTANCE.Read(reader),
+		FfiConverterSequenceUniffiPcztOutputInfoINSTANCE.Read(reader),
+		FfiConverterUint32INSTANCE.Read(reader),
+		FfiConverterBoolINSTANCE.Read(reader),
+		FfiConverterUint64INSTANCE.Read(reader),
+		FfiConverterUint64INSTANCE.Read(reader),
+		FfiConverterUint64INSTANCE.Read(reader),
+		FfiConverterBoolINSTANCE.Read(reader),
+		FfiConverterBoolINSTANCE.Read(reader),
+		FfiConverterBoolINSTANCE.Read(reader),
+		FfiConverterBoolINSTANCE.Read(reader),
+		FfiConverterBoolINSTANCE.Read(reader),
+		FfiConverterBoolINSTANCE.Read(reader),
+	}
+}
+
+func (c FfiConverterUniffiPcztInfo) Lower(value UniffiPcztInfo) C.RustBuffer {
+	return LowerIntoRustBuffer[UniffiPcztInfo](c, value)
+}
+
+func (c FfiConverterUniffiPcztInfo) Write(writer io.Writer, value UniffiPcztInfo) {
+	FfiConverterStringINSTANCE.Write(writer, value.Network)
+	FfiConverterUint32INSTANCE.Write(writer, value.ExpiryHeight)
+	FfiConverterSequenceUniffiPcztInputInfoINSTANCE.Write(writer, value.TransparentInputs)
+	FfiConverterSequenceUniffiPcztOutputInfoINSTANCE.Write(writer, value.TransparentOutputs)
+	FfiConverterUint32INSTANCE.Write(writer, value.OrchardActionCount)
+	FfiConverterBoolINSTANCE.Write(writer, value.OrchardProofsAttached)
+	FfiConverterUint64INSTANCE.Write(writer, value.TotalInputValue)
+	FfiConverterUint64INSTANCE.Write(writer, value.TotalOutputValue)
+	FfiConverterUint64INSTANCE.Write(writer, value.FeeZatoshis)
+	FfiConverterBoolINSTANCE.Write(writer, value.Created)
+	FfiConverterBoolINSTANCE.Write(writer, value.IoFinalized)
+	FfiConverterBoolINSTANCE.Write(writer, value.Updated)
+	FfiConverterBoolINSTANCE.Write(writer, value.Proven)
+	FfiConverterBoolINSTANCE.Write(writer, value.Signed)
+	FfiConverterBoolINSTANCE.Write(writer, value.Finalized)
+}
+
+type FfiDestroyerUniffiPcztInfo struct{}
+
+func (_ FfiDestroyerUniffiPcztInfo) Destroy(value UniffiPcztInfo) {
+	value.Destroy()
+}
+
+// A Sapling note to spend, with the witness data needed to prove it's
+// unspent, for ProposeShieldedTransaction
+type UniffiSaplingSpend struct {
+	// Note commitment (32 bytes as hex string)
+	NoteCommitmentHex string
+	// Nullifier (32 bytes as hex string)
+	NullifierHex string
+	// Value in zatoshis
+	Value uint64
+	// Note's rho value (32 bytes as hex string)
+	RhoHex string
+	// Note's rseed value (32 bytes as hex string)
+	RseedHex string
+	// Merkle path to the note commitment, from a lightwalletd incremental
+	// witness (hex encoded)
+	MerklePathHex string
+	// Anchor the merkle path is rooted at (32 bytes as hex string)
+	AnchorHex string
+	// Incoming viewing key able to decrypt this note (hex encoded)
+	IvkHex string
+}
+
+func (r *UniffiSaplingSpend) Destroy() {
+	FfiDestroyerString{}.Destroy(r.NoteCommitmentHex)
+	FfiDestroyerString{}.Destroy(r.NullifierHex)
+	FfiDestroyerUint64{}.Destroy(r.Value)
+	FfiDestroyerString{}.Destroy(r.RhoHex)
+	FfiDestroyerString{}.Destroy(r.RseedHex)
+	FfiDestroyerString{}.Destroy(r.MerklePathHex)
+	FfiDestroyerString{}.Destroy(r.AnchorHex)
+	FfiDestroyerString{}.Destroy(r.IvkHex)
+}
+
+type FfiConverterUniffiSaplingSpend struct{}
+
+var FfiConverterUniffiSaplingSpendINSTANCE = FfiConverterUniffiSaplingSpend{}
+
+func (c FfiConverterUniffiSaplingSpend) Lift(rb RustBufferI) UniffiSaplingSpend {
+	return LiftFromRustBuffer[UniffiSaplingSpend](c, rb)
+}
+
+func (c FfiConverterUniffiSaplingSpend) Read(reader io.Reader) UniffiSaplingSpend {
+	return UniffiSaplingSpend{
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterUint64INSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+	}
+}
+
+func (c FfiConverterUniffiSaplingSpend) Lower(value UniffiSaplingSpend) C.RustBuffer {
+	return LowerIntoRustBuffer[UniffiSaplingSpend](c, value)
+}
+
+func (c FfiConverterUniffiSaplingSpend) Write(writer io.Writer, value UniffiSaplingSpend) {
+	FfiConverterStringINSTANCE.Write(writer, value.NoteCommitmentHex)
+	FfiConverterStringINSTANCE.Write(writer, value.NullifierHex)
+	FfiConverterUint64INSTANCE.Write(writer, value.Value)
+	FfiConverterStringINSTANCE.Write(writer, value.RhoHex)
+	FfiConverterStringINSTANCE.Write(writer, value.RseedHex)
+	FfiConverterStringINSTANCE.Write(writer, value.MerklePathHex)
+	FfiConverterStringINSTANCE.Write(writer, value.AnchorHex)
+	FfiConverterStringINSTANCE.Write(writer, value.IvkHex)
+}
+
+type FfiDestroyerUniffiSaplingSpend struct{}
+
+func (_ FfiDestroyerUniffiSaplingSpend) Destroy(value UniffiSaplingSpend) {
+	value.Destroy()
+}
+
+type FfiConverterSequenceUniffiSaplingSpend struct{}
+
+var FfiConverterSequenceUniffiSaplingSpendINSTANCE = FfiConverterSequenceUniffiSaplingSpend{}
+
+func (c FfiConverterSequenceUniffiSaplingSpend) Lift(rb RustBufferI) []UniffiSaplingSpend {
+	return LiftFromRustBuffer[[]UniffiSaplingSpend](c, rb)
+}
+
+func (c FfiConverterSequenceUniffiSaplingSpend) Read(reader io.Reader) []UniffiSaplingSpend {
+	length := readInt32(reader)
+	if length == 0 {
+		return nil
+	}
+	result := make([]UniffiSaplingSpend, 0, length)
+	for i := int32(0); i < length; i++ {
+		result = append(result, FfiConverterUniffiSaplingSpendINSTANCE.Read(reader))
+	}
+	return result
+}
+
+func (c FfiConverterSequenceUniffiSaplingSpend) Lower(value []UniffiSaplingSpend) C.RustBuffer {
+	return LowerIntoRustBuffer[[]UniffiSaplingSpend](c, value)
+}
+
+func (c FfiConverterSequenceUniffiSaplingSpend) Write(writer io.Writer, value []UniffiSaplingSpend) {
+	if len(value) > math.MaxInt32 {
+		panic("[]UniffiSaplingSpend is too large to fit into Int32")
+	}
+
+	writeInt32(writer, int32(len(value)))
+	for _, item := range value {
+		FfiConverterUniffiSaplingSpendINSTANCE.Write(writer, item)
+	}
+}
+
+type FfiDestroyerSequenceUniffiSaplingSpend struct{}
+
+func (FfiDestroyerSequenceUniffiSaplingSpend) Destroy(sequence []UniffiSaplingSpend) {
+	for _, value := range sequence {
+		FfiDestroyerUniffiSaplingSpend{}.Destroy(value)
+	}
+}
+
 // Transaction request per ZIP 321 specification
 // See: https://zips.z.cash/zip-0321
 type UniffiTransactionRequest struct {
@@ -1036,6 +1729,56 @@ func (_ FfiDestroyerUniffiTransparentInput) Destroy(value UniffiTransparentInput
 	value.Destroy()
 }
 
+// Incremental-witness tree state for the shielded pools, supplied by the
+// caller from a lightwalletd sync, needed to build Orchard/Sapling spend
+// proofs in ProposeShieldedTransaction
+type UniffiWitnessAnchors struct {
+	// Orchard note commitment tree anchor (32 bytes as hex string)
+	OrchardAnchor string
+	// Sapling note commitment tree anchor (32 bytes as hex string)
+	SaplingAnchor string
+	// Size of the note commitment tree the anchors were computed at
+	TreeSize uint64
+}
+
+func (r *UniffiWitnessAnchors) Destroy() {
+	FfiDestroyerString{}.Destroy(r.OrchardAnchor)
+	FfiDestroyerString{}.Destroy(r.SaplingAnchor)
+	FfiDestroyerUint64{}.Destroy(r.TreeSize)
+}
+
+type FfiConverterUniffiWitnessAnchors struct{}
+
+var FfiConverterUniffiWitnessAnchorsINSTANCE = FfiConverterUniffiWitnessAnchors{}
+
+func (c FfiConverterUniffiWitnessAnchors) Lift(rb RustBufferI) UniffiWitnessAnchors {
+	return LiftFromRustBuffer[UniffiWitnessAnchors](c, rb)
+}
+
+func (c FfiConverterUniffiWitnessAnchors) Read(reader io.Reader) UniffiWitnessAnchors {
+	return UniffiWitnessAnchors{
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterStringINSTANCE.Read(reader),
+		FfiConverterUint64INSTANCE.Read(reader),
+	}
+}
+
+func (c FfiConverterUniffiWitnessAnchors) Lower(value UniffiWitnessAnchors) C.RustBuffer {
+	return LowerIntoRustBuffer[UniffiWitnessAnchors](c, value)
+}
+
+func (c FfiConverterUniffiWitnessAnchors) Write(writer io.Writer, value UniffiWitnessAnchors) {
+	FfiConverterStringINSTANCE.Write(writer, value.OrchardAnchor)
+	FfiConverterStringINSTANCE.Write(writer, value.SaplingAnchor)
+	FfiConverterUint64INSTANCE.Write(writer, value.TreeSize)
+}
+
+type FfiDestroyerUniffiWitnessAnchors struct{}
+
+func (_ FfiDestroyerUniffiWitnessAnchors) Destroy(value UniffiWitnessAnchors) {
+	value.Destroy()
+}
+
 type UniffiError struct {
 	err error
 }
@@ -1416,6 +2159,79 @@ func CombinePczts(pcztList []*UniffiPczt) (*UniffiPczt, error) {
 	}
 }
 
+// Previews the ZIP 317 fee and input selection for a transaction request
+// without building or proving a PCZT
+//
+// This runs the same ZIP 317 formula ProposeTransaction's proposer uses
+// (fee = ZIP317MarginalFee * max(ZIP317GraceActions, logical actions)) and
+// selects inputsAvailable largest-first, so network is accepted for
+// signature symmetry with ProposeTransaction but doesn't otherwise affect
+// the estimate. A payment or changeAddress is treated as needing an
+// Orchard bundle if it carries a memo or isn't a transparent address (see
+// isShieldedZcashAddress); this can't account for Sapling-only spends or
+// outputs ProposeShieldedTransaction would add, since those aren't
+// representable in a plain UniffiTransactionRequest.
+//
+// # Arguments
+// * `inputs_available` - Candidate UTXOs to select from
+// * `transaction_request` - ZIP 321 payment request (payments only)
+// * `change_address` - Optional address for change (transparent or Orchard)
+// * `network` - "mainnet" or "testnet"
+func EstimateFee(inputsAvailable []UniffiTransparentInput, transactionRequest UniffiTransactionRequest, changeAddress *string, network string) (UniffiFeeEstimate, error) {
+	var _uniffiDefaultValue UniffiFeeEstimate
+
+	var paymentTotal uint64
+	transparentOutputs := 0
+	orchardActions := 0
+	for _, p := range transactionRequest.Payments {
+		paymentTotal += p.Amount
+		if p.Memo != nil || isShieldedZcashAddress(p.Address) {
+			orchardActions++
+		} else {
+			transparentOutputs++
+		}
+	}
+	changeIsOrchard := changeAddress != nil && isShieldedZcashAddress(*changeAddress)
+
+	order, err := sortedInputIndices(inputsAvailable, UniffiCoinSelectionStrategyLargestFirst)
+	if err != nil {
+		return _uniffiDefaultValue, err
+	}
+
+	var selected []uint32
+	var selectedTotal uint64
+	for i := 0; ; i++ {
+		withChangeFee := zip317Fee(len(selected), transparentOutputs, orchardActions, true, changeIsOrchard)
+		if need := paymentTotal + withChangeFee; selectedTotal >= need && selectedTotal-need >= coinSelectionDustThreshold {
+			change := selectedTotal - need
+			return UniffiFeeEstimate{
+				FeeZatoshis:           withChangeFee,
+				SelectedInputIndices:  selected,
+				ChangeAmount:          change,
+				LogicalActions:        zip317LogicalActions(len(selected), transparentOutputs, orchardActions, true, changeIsOrchard),
+				RequiresOrchardBundle: orchardActions > 0 || changeIsOrchard,
+			}, nil
+		}
+
+		withoutChangeFee := zip317Fee(len(selected), transparentOutputs, orchardActions, false, changeIsOrchard)
+		if selectedTotal >= paymentTotal+withoutChangeFee {
+			return UniffiFeeEstimate{
+				FeeZatoshis:           withoutChangeFee,
+				SelectedInputIndices:  selected,
+				ChangeAmount:          0,
+				LogicalActions:        zip317LogicalActions(len(selected), transparentOutputs, orchardActions, false, changeIsOrchard),
+				RequiresOrchardBundle: orchardActions > 0,
+			}, nil
+		}
+
+		if i >= len(order) {
+			return _uniffiDefaultValue, ErrInsufficientFunds
+		}
+		selected = append(selected, uint32(order[i]))
+		selectedTotal += inputsAvailable[order[i]].Value
+	}
+}
+
 // Finalizes the PCZT and extracts the transaction bytes
 func FinalizeAndExtract(pczt *UniffiPczt) ([]byte, error) {
 	_uniffiRV, _uniffiErr := rustCallWithError[UniffiError](FfiConverterUniffiError{}, func(_uniffiStatus *C.RustCallStatus) RustBufferI {
@@ -1464,6 +2280,21 @@ func GetSighash(pczt *UniffiPczt, inputIndex uint32) (string, error) {
 	}
 }
 
+// Inspects a PCZT's contents and workflow progress, for rendering a
+// confirmation screen or deciding what to do next without guessing from
+// prior state or parsing FinalizeAndExtract errors
+//
+// NOTE: inspect_pczt has no implementation in the t2z_uniffi crate as
+// vendored in this repo, so this always returns errInspectPcztNotImplemented
+// rather than declare a cgo call against a symbol that doesn't exist.
+//
+// # Arguments
+// * `pczt` - The PCZT to inspect
+func InspectPczt(pczt *UniffiPczt) (UniffiPcztInfo, error) {
+	var _uniffiDefaultValue UniffiPcztInfo
+	return _uniffiDefaultValue, errInspectPcztNotImplemented
+}
+
 // Check if the proving key has been built and cached
 func IsProvingKeyReady() bool {
 	return FfiConverterBoolINSTANCE.Lift(rustCall(func(_uniffiStatus *C.RustCallStatus) C.int8_t {
@@ -1474,11 +2305,72 @@ func IsProvingKeyReady() bool {
 // Pre-build the Orchard proving key
 //
 // Call this at application startup to avoid blocking during transaction proving.
+// This is a thin wrapper around PrebuildProvingKeyCtx with a background
+// context and no progress reporting; use PrebuildProvingKeyCtx directly to
+// cancel or observe progress.
 func PrebuildProvingKey() {
-	rustCall(func(_uniffiStatus *C.RustCallStatus) bool {
-		C.uniffi_t2z_uniffi_fn_func_prebuild_proving_key(_uniffiStatus)
-		return false
-	})
+	if err := PrebuildProvingKeyCtx(context.Background(), ProveOptions{}); err != nil {
+		panic(err)
+	}
+}
+
+// Serializes the in-memory proving key to bytes, for callers that want to
+// persist it to disk themselves (see LoadProvingKey/SaveProvingKey). Returns
+// an error if the proving key hasn't been built yet; call PrebuildProvingKey
+// or PrebuildProvingKeyCtx first.
+//
+// NOTE: serialize_proving_key, deserialize_proving_key,
+// proving_key_version_tag, and proving_key_k_param have no implementation in
+// the t2z_uniffi crate vendored in this repo, so the four functions below
+// always return their sentinel error (or, for the two uint32 getters, 0)
+// rather than declare cgo calls against symbols that don't exist. Treat
+// LoadProvingKey/SaveProvingKey/EnsureProvingKey in provingkey.go as blocked
+// on that Rust-side work landing.
+func serializeProvingKeyBytes() ([]byte, error) {
+	return nil, errSerializeProvingKeyNotImplemented
+}
+
+// Loads a previously serialized proving key into memory, making
+// IsProvingKeyReady true without rebuilding the circuit. Returns an error if
+// data isn't a valid serialized proving key.
+func deserializeProvingKeyBytes(data []byte) error {
+	return errDeserializeProvingKeyNotImplemented
+}
+
+// Reports the format version of the proving key serialization produced by
+// serializeProvingKeyBytes. Bumped whenever the on-disk layout changes.
+func provingKeyVersionTag() uint32 {
+	return 0
+}
+
+// Reports the Halo2 circuit's k parameter (the log2 of the circuit size),
+// which the proving key was built for. A cache file built for a different k
+// must be rejected rather than loaded, since it won't match the circuit.
+func provingKeyKParam() uint32 {
+	return 0
+}
+
+// Proposes a transaction spending transparent UTXOs and/or shielded notes to
+// transparent and/or shielded outputs, covering t->z, z->z, and z->t in
+// addition to the t->t case handled by ProposeTransaction
+//
+// NOTE: propose_shielded_transaction has no implementation in the
+// t2z_uniffi crate vendored in this repo, so this always returns
+// errProposeShieldedTransactionNotImplemented rather than declare a cgo
+// call against a symbol that doesn't exist. See SignOrchardSpend and
+// SignSaplingSpend for the same situation.
+//
+// # Arguments
+// * `inputs_to_spend` - UTXOs to spend
+// * `orchard_spends` - Orchard notes to spend, with witness data
+// * `sapling_spends` - Sapling notes to spend, with witness data
+// * `anchors` - Shielded pool tree anchors the spends' merkle paths are rooted at
+// * `transaction_request` - ZIP 321 payment request (payments only)
+// * `change_address` - Optional address for change (transparent or Orchard)
+// * `network` - "mainnet" or "testnet"
+// * `expiry_height` - Transaction expiry height
+func ProposeShieldedTransaction(inputsToSpend []UniffiTransparentInput, orchardSpends []UniffiOrchardSpend, saplingSpends []UniffiSaplingSpend, anchors UniffiWitnessAnchors, transactionRequest UniffiTransactionRequest, changeAddress *string, network string, expiryHeight uint32) (*UniffiPczt, error) {
+	return nil, errProposeShieldedTransactionNotImplemented
 }
 
 // Proposes a transaction from transparent inputs to transparent and/or shielded outputs
@@ -1505,16 +2397,77 @@ func ProposeTransaction(inputsToSpend []UniffiTransparentInput, transactionReque
 //
 // This uses Halo 2, which requires NO external downloads or trusted setup.
 // The proving key is built programmatically and cached for subsequent calls.
+// This is a thin wrapper around ProveTransactionCtx with a background
+// context and no progress reporting; use ProveTransactionCtx directly to
+// cancel or observe progress.
 func ProveTransaction(pczt *UniffiPczt) (*UniffiPczt, error) {
-	_uniffiRV, _uniffiErr := rustCallWithError[UniffiError](FfiConverterUniffiError{}, func(_uniffiStatus *C.RustCallStatus) unsafe.Pointer {
-		return C.uniffi_t2z_uniffi_fn_func_prove_transaction(FfiConverterUniffiPcztINSTANCE.Lower(pczt), _uniffiStatus)
-	})
-	if _uniffiErr != nil {
-		var _uniffiDefaultValue *UniffiPczt
-		return _uniffiDefaultValue, _uniffiErr
-	} else {
-		return FfiConverterUniffiPcztINSTANCE.Lift(_uniffiRV), nil
+	return ProveTransactionCtx(context.Background(), pczt, ProveOptions{})
+}
+
+// Selects which of inputsAvailable to spend in order to cover targetValue,
+// without computing a fee or touching the network. Returns indices into
+// inputsAvailable; callers wanting a fee-aware selection should use
+// EstimateFee instead, which selects largest-first until the ZIP 317 fee
+// is covered as well.
+//
+// BranchAndBound falls back to LargestFirst if branchAndBoundIndices can't
+// find a combination summing to exactly targetValue, per its doc comment.
+func SelectInputs(inputsAvailable []UniffiTransparentInput, targetValue uint64, strategy UniffiCoinSelectionStrategy) ([]uint32, error) {
+	if strategy == UniffiCoinSelectionStrategyBranchAndBound {
+		if indices, ok := branchAndBoundIndices(inputsAvailable, targetValue); ok {
+			selected := make([]uint32, len(indices))
+			for i, idx := range indices {
+				selected[i] = uint32(idx)
+			}
+			return selected, nil
+		}
+		strategy = UniffiCoinSelectionStrategyLargestFirst
+	}
+
+	order, err := sortedInputIndices(inputsAvailable, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []uint32
+	var total uint64
+	for _, idx := range order {
+		if total >= targetValue {
+			break
+		}
+		selected = append(selected, uint32(idx))
+		total += inputsAvailable[idx].Value
 	}
+	if total < targetValue {
+		return nil, ErrInsufficientFunds
+	}
+	return selected, nil
+}
+
+// Signs an Orchard action's spend with the provided spending key, for PCZTs
+// proposed with ProposeShieldedTransaction. Composes with
+// SignSaplingSpend/SignTransparentInput across separate PCZT copies, which
+// are then joined with CombinePczts before ProveTransaction.
+//
+// NOTE: sign_orchard_spend has no implementation in the t2z_uniffi crate
+// vendored in this repo, so this always returns
+// errSignOrchardSpendNotImplemented rather than declare a cgo call against
+// a symbol that doesn't exist.
+func SignOrchardSpend(pczt *UniffiPczt, actionIndex uint32, spendingKeyHex string) (*UniffiPczt, error) {
+	return nil, errSignOrchardSpendNotImplemented
+}
+
+// Signs a Sapling spend with the provided expanded spending key, for PCZTs
+// proposed with ProposeShieldedTransaction. Composes with
+// SignOrchardSpend/SignTransparentInput across separate PCZT copies, which
+// are then joined with CombinePczts before ProveTransaction.
+//
+// NOTE: sign_sapling_spend has no implementation in the t2z_uniffi crate
+// vendored in this repo, so this always returns
+// errSignSaplingSpendNotImplemented rather than declare a cgo call against
+// a symbol that doesn't exist.
+func SignSaplingSpend(pczt *UniffiPczt, spendIndex uint32, expskHex string) (*UniffiPczt, error) {
+	return nil, errSignSaplingSpendNotImplemented
 }
 
 // Signs a transparent input with the provided private key
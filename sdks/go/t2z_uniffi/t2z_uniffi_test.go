@@ -1,6 +1,8 @@
 package t2z_uniffi
 
 import (
+	"bytes"
+	"io"
 	"strings"
 	"testing"
 )
@@ -394,3 +396,171 @@ func TestProposeTransactionEmptyInputs(t *testing.T) {
 	}
 	t.Logf("Expected error received: %s", err.Error())
 }
+
+// chunkedReader returns at most chunkSize bytes per Read call, modelling a
+// reader that never fills the caller's buffer in one shot (e.g. a streaming
+// PCZT combine or an io.LimitedReader wrapper).
+type chunkedReader struct {
+	data      []byte
+	chunkSize int
+}
+
+func (r *chunkedReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, io.EOF
+	}
+	n := r.chunkSize
+	if n > len(p) {
+		n = len(p)
+	}
+	if n > len(r.data) {
+		n = len(r.data)
+	}
+	copy(p, r.data[:n])
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func TestFfiConverterStringReadShortReads(t *testing.T) {
+	var buf bytes.Buffer
+	FfiConverterStringINSTANCE.Write(&buf, "hello from a chunked reader")
+	got := FfiConverterStringINSTANCE.Read(&chunkedReader{data: buf.Bytes(), chunkSize: 3})
+	if got != "hello from a chunked reader" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestFfiConverterBytesReadShortReads(t *testing.T) {
+	want := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	var buf bytes.Buffer
+	FfiConverterBytesINSTANCE.Write(&buf, want)
+	got := FfiConverterBytesINSTANCE.Read(&chunkedReader{data: buf.Bytes(), chunkSize: 2})
+	if !bytes.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestEstimateFeeCoversPaymentAndFee(t *testing.T) {
+	inputs := []UniffiTransparentInput{
+		{PrevoutTxid: "a", Value: 100000},
+		{PrevoutTxid: "b", Value: 5000000},
+	}
+	request := UniffiTransactionRequest{
+		Payments: []UniffiPayment{{Address: "t1test", Amount: 100000}},
+	}
+
+	estimate, err := EstimateFee(inputs, request, nil, "testnet")
+	if err != nil {
+		t.Fatalf("EstimateFee: %v", err)
+	}
+	if estimate.FeeZatoshis == 0 {
+		t.Error("expected a non-zero fee")
+	}
+	var selectedTotal uint64
+	for _, idx := range estimate.SelectedInputIndices {
+		selectedTotal += inputs[idx].Value
+	}
+	if selectedTotal < 100000+estimate.FeeZatoshis {
+		t.Errorf("selected inputs totalling %d don't cover payment+fee %d", selectedTotal, 100000+estimate.FeeZatoshis)
+	}
+}
+
+func TestEstimateFeeInsufficientFunds(t *testing.T) {
+	inputs := []UniffiTransparentInput{{PrevoutTxid: "a", Value: 1000}}
+	request := UniffiTransactionRequest{
+		Payments: []UniffiPayment{{Address: "t1test", Amount: 100000}},
+	}
+
+	_, err := EstimateFee(inputs, request, nil, "testnet")
+	if err != ErrInsufficientFunds {
+		t.Errorf("expected ErrInsufficientFunds, got %v", err)
+	}
+}
+
+func TestEstimateFeeRequiresOrchardBundleForShieldedPayment(t *testing.T) {
+	inputs := []UniffiTransparentInput{{PrevoutTxid: "a", Value: 5000000}}
+	request := UniffiTransactionRequest{
+		Payments: []UniffiPayment{{Address: "u1shielded", Amount: 100000}},
+	}
+
+	estimate, err := EstimateFee(inputs, request, nil, "testnet")
+	if err != nil {
+		t.Fatalf("EstimateFee: %v", err)
+	}
+	if !estimate.RequiresOrchardBundle {
+		t.Error("expected RequiresOrchardBundle for a shielded payment address")
+	}
+}
+
+func TestSelectInputsLargestFirst(t *testing.T) {
+	inputs := []UniffiTransparentInput{
+		{PrevoutTxid: "a", Value: 100},
+		{PrevoutTxid: "b", Value: 500},
+		{PrevoutTxid: "c", Value: 200},
+	}
+	got, err := SelectInputs(inputs, 150, UniffiCoinSelectionStrategyLargestFirst)
+	if err != nil {
+		t.Fatalf("SelectInputs: %v", err)
+	}
+	if len(got) != 1 || got[0] != 1 {
+		t.Fatalf("expected just the largest input (index 1), got %v", got)
+	}
+}
+
+func TestSelectInputsSmallestFirst(t *testing.T) {
+	inputs := []UniffiTransparentInput{
+		{PrevoutTxid: "a", Value: 100},
+		{PrevoutTxid: "b", Value: 500},
+		{PrevoutTxid: "c", Value: 200},
+	}
+	got, err := SelectInputs(inputs, 250, UniffiCoinSelectionStrategySmallestFirst)
+	if err != nil {
+		t.Fatalf("SelectInputs: %v", err)
+	}
+	if len(got) != 2 || got[0] != 0 || got[1] != 2 {
+		t.Fatalf("expected the two smallest inputs (indices 0, 2), got %v", got)
+	}
+}
+
+func TestSelectInputsInsufficientFunds(t *testing.T) {
+	inputs := []UniffiTransparentInput{{PrevoutTxid: "a", Value: 100}}
+	_, err := SelectInputs(inputs, 1000, UniffiCoinSelectionStrategyLargestFirst)
+	if err != ErrInsufficientFunds {
+		t.Errorf("expected ErrInsufficientFunds, got %v", err)
+	}
+}
+
+func TestSelectInputsBranchAndBoundExactMatch(t *testing.T) {
+	inputs := []UniffiTransparentInput{
+		{PrevoutTxid: "a", Value: 100},
+		{PrevoutTxid: "b", Value: 300},
+		{PrevoutTxid: "c", Value: 250},
+	}
+	got, err := SelectInputs(inputs, 350, UniffiCoinSelectionStrategyBranchAndBound)
+	if err != nil {
+		t.Fatalf("SelectInputs: %v", err)
+	}
+	var total uint64
+	for _, idx := range got {
+		total += inputs[idx].Value
+	}
+	if total != 350 {
+		t.Errorf("expected an exact match summing to 350, got indices %v summing to %d", got, total)
+	}
+}
+
+func TestSelectInputsBranchAndBoundFallsBackToLargestFirst(t *testing.T) {
+	// No subset of these sums to exactly 150, so BranchAndBound must fall
+	// back to LargestFirst rather than error.
+	inputs := []UniffiTransparentInput{
+		{PrevoutTxid: "a", Value: 100},
+		{PrevoutTxid: "b", Value: 500},
+	}
+	got, err := SelectInputs(inputs, 150, UniffiCoinSelectionStrategyBranchAndBound)
+	if err != nil {
+		t.Fatalf("SelectInputs: %v", err)
+	}
+	if len(got) != 1 || got[0] != 1 {
+		t.Fatalf("expected LargestFirst fallback to pick index 1, got %v", got)
+	}
+}
@@ -0,0 +1,67 @@
+package t2z_uniffi
+
+import "errors"
+
+// errProposeTransactionUnconfirmedNotImplemented is returned by
+// ProposeTransactionUnconfirmed.
+//
+// NOTE: propose_transaction_unconfirmed has no implementation in the
+// t2z_uniffi crate vendored in this repo, so this always returns the
+// sentinel error below rather than declare a cgo call against a symbol
+// that doesn't exist, same as the other NOTE'd stubs in t2z_uniffi.go and
+// ctx.go.
+var errProposeTransactionUnconfirmedNotImplemented = errors.New("t2z_uniffi: ProposeTransactionUnconfirmed is not yet implemented (blocked on the propose_transaction_unconfirmed rust export)")
+
+// UniffiUnconfirmedTransparentInput is a transparent input whose prevout
+// transaction hasn't been mined yet, spent via ProposeTransactionUnconfirmed
+// instead of ProposeTransaction.
+//
+// This is a separate type rather than Unconfirmed/ParentRawTx fields added
+// onto UniffiTransparentInput: an earlier version of this change added
+// them directly to UniffiTransparentInput, which silently changed that
+// struct's wire format for every ProposeTransaction call with no matching
+// Rust-side field, and was reverted. Keeping chained inputs on their own
+// type leaves ProposeTransaction's existing, Rust-backed wire format
+// untouched.
+type UniffiUnconfirmedTransparentInput struct {
+	// Public key (33 bytes as hex string)
+	Pubkey string
+	// Previous transaction ID (32 bytes as hex string)
+	PrevoutTxid string
+	// Previous output index
+	PrevoutIndex uint32
+	// Value in zatoshis
+	Value uint64
+	// Script pubkey (hex encoded)
+	ScriptPubkey string
+	// Optional sequence number
+	Sequence *uint32
+	// The raw parent transaction (hex encoded) that created this outpoint.
+	// The prevout isn't in a confirmed UTXO set yet, so the script and
+	// value above are checked against this transaction's output at
+	// PrevoutIndex instead of a confirmed lookup.
+	ParentRawTx string
+}
+
+// ProposeTransactionUnconfirmed proposes a transaction like
+// ProposeTransaction, but spending inputs whose prevout transaction hasn't
+// been mined yet, resolving each input's script/value against its
+// ParentRawTx rather than a confirmed UTXO set. Spending a further
+// unconfirmed input (a chain of unmined transactions) requires supplying
+// the raw transaction closest to that input; this only ever needs one
+// level of parent context per input.
+//
+// NOTE: propose_transaction_unconfirmed has no implementation in the
+// t2z_uniffi crate vendored in this repo, so this always returns
+// errProposeTransactionUnconfirmedNotImplemented rather than declare a cgo
+// call against a symbol that doesn't exist.
+//
+// # Arguments
+// * `inputs_to_spend` - unconfirmed UTXOs to spend, each with its parent raw tx
+// * `transaction_request` - ZIP 321 payment request (payments only)
+// * `change_address` - Optional address for change (transparent or Orchard)
+// * `network` - "mainnet" or "testnet"
+// * `expiry_height` - Transaction expiry height
+func ProposeTransactionUnconfirmed(inputsToSpend []UniffiUnconfirmedTransparentInput, transactionRequest UniffiTransactionRequest, changeAddress *string, network string, expiryHeight uint32) (*UniffiPczt, error) {
+	return nil, errProposeTransactionUnconfirmedNotImplemented
+}
@@ -0,0 +1,52 @@
+package t2z_uniffi
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestProposeTransactionUnconfirmedNotImplemented pins
+// ProposeTransactionUnconfirmed's documented sentinel-error behavior. The
+// request asked for tests covering (a) a valid chained input with a parent
+// transaction, (b) an unconfirmed input with no parent context (should
+// error), and (c) a two-deep chain - but ProposeTransactionUnconfirmed is a
+// hard stub pending the propose_transaction_unconfirmed rust export, so
+// none of those three can be driven to a real outcome yet. This test at
+// least makes the gap visible: it fails the moment the stub starts
+// returning something other than errProposeTransactionUnconfirmedNotImplemented,
+// which is exactly when the three scenarios above should be written for
+// real, analogous to TestProposeTransactionInvalidAddress.
+func TestProposeTransactionUnconfirmedNotImplemented(t *testing.T) {
+	parentInput := UniffiUnconfirmedTransparentInput{
+		Pubkey:       "02" + strings.Repeat("ab", 32),
+		PrevoutTxid:  strings.Repeat("00", 32),
+		PrevoutIndex: 0,
+		Value:        1000000,
+		ScriptPubkey: "76a914" + strings.Repeat("00", 20) + "88ac",
+		Sequence:     nil,
+		ParentRawTx:  "01" + strings.Repeat("00", 63),
+	}
+
+	payment := UniffiPayment{
+		Address: "tm9iMLAuYMzJ6jtFLcA7rCD2s5IKoMZieHR",
+		Amount:  500000,
+		Memo:    nil,
+		Label:   nil,
+	}
+	request := UniffiTransactionRequest{
+		Payments: []UniffiPayment{payment},
+	}
+
+	_, err := ProposeTransactionUnconfirmed(
+		[]UniffiUnconfirmedTransparentInput{parentInput},
+		request,
+		nil,
+		"testnet",
+		3720100,
+	)
+
+	if !errors.Is(err, errProposeTransactionUnconfirmedNotImplemented) {
+		t.Errorf("expected errProposeTransactionUnconfirmedNotImplemented, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+// Package xput is a throughput and latency benchmarking harness for
+// ProposeTransaction. Given a pool of synthetic funded transparent inputs,
+// it drives concurrent workers through repeated proposals for a fixed
+// duration and reports proposals/sec, latency percentiles, and an error
+// breakdown by kind, similar in spirit to the AVM xput wallet harness.
+package xput
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	t2z "github.com/d4mr/t2z/sdks/go/t2z_uniffi"
+)
+
+// GenerateInputs synthesizes n funded transparent inputs for benchmarking,
+// each with a distinct prevout and enough value to cover several payments
+// plus fees on its own.
+func GenerateInputs(n int) []t2z.UniffiTransparentInput {
+	inputs := make([]t2z.UniffiTransparentInput, n)
+	for i := range inputs {
+		inputs[i] = t2z.UniffiTransparentInput{
+			Pubkey:       "02" + strings.Repeat("ab", 32),
+			PrevoutTxid:  fmt.Sprintf("%064x", i+1),
+			PrevoutIndex: 0,
+			Value:        10_000_000, // 0.1 ZEC, comfortably covers fees
+			ScriptPubkey: "76a914" + strings.Repeat("00", 20) + "88ac",
+		}
+	}
+	return inputs
+}
+
+// ErrorKind buckets a ProposeTransaction error for reporting. It's a
+// coarse substring match against the message UniffiErrorError carries,
+// since that's the only structure Go callers currently get out of a
+// proposal failure (see t2z_uniffi.UniffiErrorError).
+type ErrorKind string
+
+const (
+	ErrorKindInvalidAddress ErrorKind = "invalid_address"
+	ErrorKindInsufficient   ErrorKind = "insufficient_funds"
+	ErrorKindOther          ErrorKind = "other"
+)
+
+func classifyError(err error) ErrorKind {
+	msg := strings.ToLower(err.Error())
+	switch {
+	case strings.Contains(msg, "address"):
+		return ErrorKindInvalidAddress
+	case strings.Contains(msg, "insufficient"), strings.Contains(msg, "fund"):
+		return ErrorKindInsufficient
+	default:
+		return ErrorKindOther
+	}
+}
+
+// Config controls one harness Run.
+type Config struct {
+	// Inputs is the pool of synthetic funded transparent inputs each
+	// worker draws from; see GenerateInputs.
+	Inputs []t2z.UniffiTransparentInput
+	// Workers is the number of concurrent goroutines calling
+	// ProposeTransaction.
+	Workers int
+	// MaxPaymentsPerRequest bounds payments per proposal; each request
+	// uses a count drawn uniformly from [1, MaxPaymentsPerRequest].
+	MaxPaymentsPerRequest int
+	// ChangeAddress, Network, and ExpiryHeight are passed through to every
+	// ProposeTransaction call.
+	ChangeAddress *string
+	Network       string
+	ExpiryHeight  uint32
+	// Duration bounds how long Run drives proposals for.
+	Duration time.Duration
+}
+
+// Stats summarizes one Run: throughput, latency percentiles, and an error
+// breakdown by ErrorKind.
+type Stats struct {
+	Proposals    int
+	Errors       int
+	ErrorsByKind map[ErrorKind]int
+	Elapsed      time.Duration
+	P50          time.Duration
+	P95          time.Duration
+	P99          time.Duration
+}
+
+// ProposalsPerSec counts every call toward throughput, successes and
+// failures alike, divided by Elapsed.
+func (s Stats) ProposalsPerSec() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Proposals) / s.Elapsed.Seconds()
+}
+
+// Run drives cfg.Workers goroutines, each repeatedly building a
+// UniffiTransactionRequest with a random number of synthetic payments
+// (1..cfg.MaxPaymentsPerRequest) and calling ProposeTransaction against a
+// random subset of cfg.Inputs, until cfg.Duration elapses.
+func Run(cfg Config) Stats {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Duration)
+	defer cancel()
+
+	var mu sync.Mutex
+	var latencies []time.Duration
+	errorsByKind := map[ErrorKind]int{}
+	var errCount int
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for w := 0; w < cfg.Workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(seed))
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				request := randomRequest(rng, cfg.MaxPaymentsPerRequest)
+				inputs := randomInputs(rng, cfg.Inputs)
+
+				callStart := time.Now()
+				_, err := t2z.ProposeTransaction(inputs, request, cfg.ChangeAddress, cfg.Network, cfg.ExpiryHeight)
+				latency := time.Since(callStart)
+
+				mu.Lock()
+				latencies = append(latencies, latency)
+				if err != nil {
+					errCount++
+					errorsByKind[classifyError(err)]++
+				}
+				mu.Unlock()
+			}
+		}(int64(w))
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	return Stats{
+		Proposals:    len(latencies),
+		Errors:       errCount,
+		ErrorsByKind: errorsByKind,
+		Elapsed:      elapsed,
+		P50:          percentile(latencies, 0.50),
+		P95:          percentile(latencies, 0.95),
+		P99:          percentile(latencies, 0.99),
+	}
+}
+
+// percentile returns the p-th percentile of sorted, which must already be
+// sorted ascending.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := int(p * float64(len(sorted)))
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+func randomRequest(rng *rand.Rand, maxPayments int) t2z.UniffiTransactionRequest {
+	if maxPayments < 1 {
+		maxPayments = 1
+	}
+	n := 1 + rng.Intn(maxPayments)
+	payments := make([]t2z.UniffiPayment, n)
+	for i := range payments {
+		payments[i] = t2z.UniffiPayment{
+			Address: "t1test",
+			Amount:  uint64(1000 + rng.Intn(100000)),
+		}
+	}
+	return t2z.UniffiTransactionRequest{Payments: payments}
+}
+
+// randomInputs returns a random, non-empty subset of pool, so each
+// proposal exercises a different input count instead of always spending
+// everything available.
+func randomInputs(rng *rand.Rand, pool []t2z.UniffiTransparentInput) []t2z.UniffiTransparentInput {
+	if len(pool) == 0 {
+		return nil
+	}
+	n := 1 + rng.Intn(len(pool))
+	shuffled := append([]t2z.UniffiTransparentInput(nil), pool...)
+	rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+	return shuffled[:n]
+}
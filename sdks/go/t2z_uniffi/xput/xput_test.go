@@ -0,0 +1,118 @@
+package xput
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{
+		1 * time.Millisecond,
+		2 * time.Millisecond,
+		3 * time.Millisecond,
+		4 * time.Millisecond,
+		5 * time.Millisecond,
+	}
+	if got := percentile(sorted, 0); got != 1*time.Millisecond {
+		t.Errorf("p0 = %v, want %v", got, 1*time.Millisecond)
+	}
+	if got := percentile(sorted, 0.99); got != 5*time.Millisecond {
+		t.Errorf("p99 = %v, want %v", got, 5*time.Millisecond)
+	}
+	if got := percentile(nil, 0.5); got != 0 {
+		t.Errorf("percentile of empty set = %v, want 0", got)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	cases := map[string]ErrorKind{
+		"Invalid address for network":  ErrorKindInvalidAddress,
+		"insufficient funds available": ErrorKindInsufficient,
+		"not enough funds":             ErrorKindInsufficient,
+		"proving key not ready":        ErrorKindOther,
+	}
+	for msg, want := range cases {
+		if got := classifyError(errorString(msg)); got != want {
+			t.Errorf("classifyError(%q) = %q, want %q", msg, got, want)
+		}
+	}
+}
+
+// errorString is a minimal error implementation so each case's message
+// reaches classifyError verbatim, without the "UniffiError: Error: Msg="
+// prefix a wrapped UniffiErrorError adds to its Error() string.
+type errorString string
+
+func (e errorString) Error() string { return string(e) }
+
+func TestGenerateInputsDistinctPrevouts(t *testing.T) {
+	inputs := GenerateInputs(10)
+	if len(inputs) != 10 {
+		t.Fatalf("len(inputs) = %d, want 10", len(inputs))
+	}
+	seen := map[string]bool{}
+	for _, in := range inputs {
+		if seen[in.PrevoutTxid] {
+			t.Fatalf("duplicate prevout txid %s", in.PrevoutTxid)
+		}
+		seen[in.PrevoutTxid] = true
+	}
+}
+
+// BenchmarkProposeTransaction drives ProposeTransaction at a range of
+// worker counts and payment fanouts, reporting proposals/sec and latency
+// percentiles as custom metrics. Run with e.g.
+// `go test -bench=ProposeTransaction -benchtime=2s ./sdks/go/t2z_uniffi/xput`.
+// Each proposal is a real ProposeTransaction call into the native
+// t2z_uniffi library, which this package, like t2z_uniffi itself, needs
+// linked in order to build and run.
+func BenchmarkProposeTransaction(b *testing.B) {
+	inputs := GenerateInputs(64)
+	changeAddr := "t1change"
+
+	for _, workers := range []int{1, 4, 16} {
+		for _, fanout := range []int{1, 4} {
+			name := benchName(workers, fanout)
+			b.Run(name, func(b *testing.B) {
+				stats := Run(Config{
+					Inputs:                inputs,
+					Workers:               workers,
+					MaxPaymentsPerRequest: fanout,
+					ChangeAddress:         &changeAddr,
+					Network:               "testnet",
+					ExpiryHeight:          3720100,
+					Duration:              benchDuration(b),
+				})
+				b.ReportMetric(stats.ProposalsPerSec(), "proposals/sec")
+				b.ReportMetric(float64(stats.P50.Microseconds()), "p50-us")
+				b.ReportMetric(float64(stats.P95.Microseconds()), "p95-us")
+				b.ReportMetric(float64(stats.P99.Microseconds()), "p99-us")
+				if stats.Proposals > 0 {
+					b.ReportMetric(float64(stats.Errors)/float64(stats.Proposals), "error-rate")
+				}
+			})
+		}
+	}
+}
+
+// benchDuration scales with b.N so `-benchtime` controls how long each
+// sub-benchmark runs, the same knob testing.B users already expect.
+func benchDuration(b *testing.B) time.Duration {
+	n := b.N
+	if n < 1 {
+		n = 1
+	}
+	d := time.Duration(n) * time.Millisecond
+	if d > 5*time.Second {
+		d = 5 * time.Second
+	}
+	if d < 50*time.Millisecond {
+		d = 50 * time.Millisecond
+	}
+	return d
+}
+
+func benchName(workers, fanout int) string {
+	return "workers=" + strconv.Itoa(workers) + "/fanout=" + strconv.Itoa(fanout)
+}
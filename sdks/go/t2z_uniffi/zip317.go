@@ -0,0 +1,53 @@
+package t2z_uniffi
+
+import "strings"
+
+// ZIP317MarginalFee is the fee, in zatoshis, charged per logical action
+// above ZIP317GraceActions, as specified by ZIP 317
+// (https://zips.z.cash/zip-0317).
+const ZIP317MarginalFee = 5000
+
+// ZIP317GraceActions is the number of logical actions a transaction is
+// charged for at minimum, even if it has fewer.
+const ZIP317GraceActions = 2
+
+// zip317LogicalActions counts the logical actions a transaction with
+// numTransparentIn transparent inputs, numTransparentOut transparent
+// outputs (payments only), and numOrchardActions Orchard-pool
+// payments/memos is charged for under ZIP 317: the larger of the
+// transparent input/output counts, plus one action per Orchard payment.
+// hasChange/changeIsOrchard add the change output itself, to whichever
+// pool it lands in.
+func zip317LogicalActions(numTransparentIn, numTransparentOut, numOrchardActions int, hasChange, changeIsOrchard bool) uint32 {
+	if hasChange {
+		if changeIsOrchard {
+			numOrchardActions++
+		} else {
+			numTransparentOut++
+		}
+	}
+	transparentActions := numTransparentIn
+	if numTransparentOut > transparentActions {
+		transparentActions = numTransparentOut
+	}
+	return uint32(transparentActions + numOrchardActions)
+}
+
+// zip317Fee computes the ZIP 317 conventional fee for a transaction with the
+// given shape: marginal_fee * max(grace_actions, logical_actions).
+func zip317Fee(numTransparentIn, numTransparentOut, numOrchardActions int, hasChange, changeIsOrchard bool) uint64 {
+	actions := uint64(zip317LogicalActions(numTransparentIn, numTransparentOut, numOrchardActions, hasChange, changeIsOrchard))
+	if actions < ZIP317GraceActions {
+		actions = ZIP317GraceActions
+	}
+	return ZIP317MarginalFee * actions
+}
+
+// isShieldedZcashAddress reports whether addr is a shielded or unified
+// address rather than a transparent one, based on the human-readable
+// prefixes Zcash address encodings use: every transparent address (mainnet
+// t1/t3, testnet tm/t2) starts with "t", and every Sapling or
+// Orchard-capable unified address doesn't.
+func isShieldedZcashAddress(addr string) bool {
+	return !strings.HasPrefix(addr, "t")
+}
@@ -0,0 +1,61 @@
+package t2z_uniffi
+
+import "testing"
+
+func TestZip317LogicalActionsTransparentOnly(t *testing.T) {
+	// 2 inputs, 1 output, no change: max(2, 1) = 2 transparent actions.
+	got := zip317LogicalActions(2, 1, 0, false, false)
+	if got != 2 {
+		t.Errorf("got %d, want 2", got)
+	}
+}
+
+func TestZip317LogicalActionsCountsChange(t *testing.T) {
+	// 1 input, 1 output, plus a transparent change output: max(1, 2) = 2.
+	got := zip317LogicalActions(1, 1, 0, true, false)
+	if got != 2 {
+		t.Errorf("got %d, want 2", got)
+	}
+}
+
+func TestZip317LogicalActionsOrchardChangeAddsOrchardAction(t *testing.T) {
+	// 1 input, 1 transparent output, plus Orchard change: max(1,1) + 1 = 2.
+	got := zip317LogicalActions(1, 1, 0, true, true)
+	if got != 2 {
+		t.Errorf("got %d, want 2", got)
+	}
+}
+
+func TestZip317FeeAppliesGraceActions(t *testing.T) {
+	// A single input/output transaction (1 logical action) is still
+	// charged for ZIP317GraceActions.
+	got := zip317Fee(1, 1, 0, false, false)
+	want := uint64(ZIP317MarginalFee * ZIP317GraceActions)
+	if got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+}
+
+func TestZip317FeeAboveGraceActions(t *testing.T) {
+	// 5 inputs, 1 output: 5 logical actions, above the grace floor.
+	got := zip317Fee(5, 1, 0, false, false)
+	want := uint64(ZIP317MarginalFee * 5)
+	if got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+}
+
+func TestIsShieldedZcashAddress(t *testing.T) {
+	cases := map[string]bool{
+		"t1abc":            false,
+		"tmabc":            false,
+		"u1addr":           true,
+		"zs1sapling":       true,
+		"ztestsapling1abc": true,
+	}
+	for addr, want := range cases {
+		if got := isShieldedZcashAddress(addr); got != want {
+			t.Errorf("isShieldedZcashAddress(%q) = %v, want %v", addr, got, want)
+		}
+	}
+}
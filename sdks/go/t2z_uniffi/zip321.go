@@ -0,0 +1,324 @@
+package t2z_uniffi
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// zatoshisPerZec is the number of zatoshis in one ZEC (8 decimal places).
+const zatoshisPerZec = 100_000_000
+
+const zip321Scheme = "zcash:"
+
+var zip321IndexedKey = regexp.MustCompile(`^(address|amount|memo|label|message)\.(\d+)$`)
+
+// ParseZip321URI parses a ZIP 321 payment URI (https://zips.z.cash/zip-0321)
+// into a UniffiTransactionRequest. Both URI forms are accepted: the
+// single-address short form (zcash:<addr>?amount=...&memo=...&label=...)
+// and the indexed multi-payment form
+// (zcash:?address=<a>&amount=...&address.1=<b>&amount.1=...). network is
+// reserved for address-network validation symmetry with ProposeTransaction.
+func ParseZip321URI(uri string, network string) (UniffiTransactionRequest, error) {
+	if !strings.HasPrefix(uri, zip321Scheme) {
+		return UniffiTransactionRequest{}, fmt.Errorf("zip321: missing %q scheme", zip321Scheme)
+	}
+	rest := uri[len(zip321Scheme):]
+
+	addrPart, queryPart, _ := strings.Cut(rest, "?")
+	addrPart, err := url.PathUnescape(addrPart)
+	if err != nil {
+		return UniffiTransactionRequest{}, fmt.Errorf("zip321: decoding leading address: %w", err)
+	}
+
+	if err := zip321CheckDuplicateParams(queryPart); err != nil {
+		return UniffiTransactionRequest{}, err
+	}
+	values, err := url.ParseQuery(queryPart)
+	if err != nil {
+		return UniffiTransactionRequest{}, fmt.Errorf("zip321: parsing query: %w", err)
+	}
+
+	var payments []UniffiPayment
+	if addrPart != "" {
+		// Single-address short form: the leading address is the only
+		// payment, and any indexed query parameters are out of grammar.
+		payment, err := zip321ParsePayment(addrPart, values, -1)
+		if err != nil {
+			return UniffiTransactionRequest{}, err
+		}
+		payments = append(payments, payment)
+	} else {
+		indices, err := zip321Indices(values)
+		if err != nil {
+			return UniffiTransactionRequest{}, err
+		}
+		for _, idx := range indices {
+			addr := zip321Get(values, "address", idx)
+			if addr == "" {
+				return UniffiTransactionRequest{}, fmt.Errorf("zip321: missing address for index %d", idx)
+			}
+			payment, err := zip321ParsePayment(addr, values, idx)
+			if err != nil {
+				return UniffiTransactionRequest{}, err
+			}
+			payments = append(payments, payment)
+		}
+	}
+
+	if len(payments) == 0 {
+		return UniffiTransactionRequest{}, fmt.Errorf("zip321: URI has no payments")
+	}
+	return UniffiTransactionRequest{Payments: payments}, nil
+}
+
+// FormatZip321URI renders a UniffiTransactionRequest as a ZIP 321 URI,
+// inverse to ParseZip321URI. A single payment is rendered in the short
+// address form; two or more use the indexed multi-payment form.
+func FormatZip321URI(req UniffiTransactionRequest, network string) (string, error) {
+	if len(req.Payments) == 0 {
+		return "", fmt.Errorf("zip321: transaction request has no payments")
+	}
+
+	if len(req.Payments) == 1 {
+		p := req.Payments[0]
+		pairs, err := zip321PaymentPairs(p, -1)
+		if err != nil {
+			return "", err
+		}
+		uri := zip321Scheme + url.PathEscape(p.Address)
+		if len(pairs) > 0 {
+			uri += "?" + strings.Join(pairs, "&")
+		}
+		return uri, nil
+	}
+
+	var allPairs []string
+	for i, p := range req.Payments {
+		allPairs = append(allPairs, fmt.Sprintf("%s=%s", zip321Key("address", i), url.QueryEscape(p.Address)))
+		pairs, err := zip321PaymentPairs(p, i)
+		if err != nil {
+			return "", err
+		}
+		allPairs = append(allPairs, pairs...)
+	}
+	return zip321Scheme + "?" + strings.Join(allPairs, "&"), nil
+}
+
+func zip321CheckDuplicateParams(queryPart string) error {
+	if queryPart == "" {
+		return nil
+	}
+	seen := map[string]bool{}
+	for _, pair := range strings.Split(queryPart, "&") {
+		if pair == "" {
+			continue
+		}
+		rawKey, _, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			return fmt.Errorf("zip321: decoding parameter name: %w", err)
+		}
+		if seen[key] {
+			return fmt.Errorf("zip321: duplicate parameter %q", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// zip321Indices collects the indices used by indexed parameters (e.g.
+// address.1, amount.2) and validates they're contiguous starting at 0 or 1,
+// per the ZIP 321 grammar. Per spec, the unsuffixed form of a parameter
+// (e.g. bare "address") is equivalent to the ".0" suffix, so it's folded
+// into index 0 here.
+func zip321Indices(values url.Values) ([]int, error) {
+	seen := map[int]bool{}
+	for key := range values {
+		switch key {
+		case "address", "amount", "memo", "label", "message":
+			seen[0] = true
+			continue
+		}
+		m := zip321IndexedKey.FindStringSubmatch(key)
+		if m == nil {
+			continue
+		}
+		idx, err := strconv.Atoi(m[2])
+		if err != nil {
+			return nil, fmt.Errorf("zip321: invalid index in parameter %q", key)
+		}
+		seen[idx] = true
+	}
+	if len(seen) == 0 {
+		return nil, nil
+	}
+	indices := make([]int, 0, len(seen))
+	for idx := range seen {
+		indices = append(indices, idx)
+	}
+	sort.Ints(indices)
+
+	start := indices[0]
+	if start != 0 && start != 1 {
+		return nil, fmt.Errorf("zip321: indexed parameters must start at 0 or 1, got %d", start)
+	}
+	for i, idx := range indices {
+		if idx != start+i {
+			return nil, fmt.Errorf("zip321: indexed parameters must be contiguous, missing index %d", start+i)
+		}
+	}
+	return indices, nil
+}
+
+func zip321Key(name string, idx int) string {
+	if idx < 0 {
+		return name
+	}
+	return fmt.Sprintf("%s.%d", name, idx)
+}
+
+// zip321Get reads a parameter at the given payment index, accepting either
+// the explicit ".0" suffix or the unsuffixed form for index 0 (both are
+// equivalent per ZIP 321).
+func zip321Get(values url.Values, name string, idx int) string {
+	if idx < 0 {
+		return values.Get(name)
+	}
+	if v := values.Get(fmt.Sprintf("%s.%d", name, idx)); v != "" {
+		return v
+	}
+	if idx == 0 {
+		return values.Get(name)
+	}
+	return ""
+}
+
+func zip321ParsePayment(addr string, values url.Values, idx int) (UniffiPayment, error) {
+	var amount uint64
+	if amountStr := zip321Get(values, "amount", idx); amountStr != "" {
+		a, err := parseZecAmount(amountStr)
+		if err != nil {
+			return UniffiPayment{}, fmt.Errorf("zip321: parsing amount for %q: %w", addr, err)
+		}
+		amount = a
+	}
+
+	var memo *string
+	if m := zip321Get(values, "memo", idx); m != "" {
+		if isTransparentAddress(addr) {
+			return UniffiPayment{}, fmt.Errorf("zip321: memo not allowed for transparent address %q", addr)
+		}
+		decoded, err := base64.RawURLEncoding.DecodeString(m)
+		if err != nil {
+			return UniffiPayment{}, fmt.Errorf("zip321: decoding memo for %q: %w", addr, err)
+		}
+		memoHex := hex.EncodeToString(decoded)
+		memo = &memoHex
+	}
+
+	var label *string
+	if l := zip321Get(values, "label", idx); l != "" {
+		label = &l
+	}
+
+	// The ZIP 321 "message" parameter is a recipient-facing note with no
+	// counterpart on UniffiPayment, so it's accepted (to avoid rejecting
+	// otherwise-valid URIs) but intentionally dropped here.
+
+	return UniffiPayment{
+		Address: addr,
+		Amount:  amount,
+		Memo:    memo,
+		Label:   label,
+	}, nil
+}
+
+func zip321PaymentPairs(p UniffiPayment, idx int) ([]string, error) {
+	var pairs []string
+	if p.Amount > 0 {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", zip321Key("amount", idx), formatZecAmount(p.Amount)))
+	}
+	if p.Memo != nil {
+		if isTransparentAddress(p.Address) {
+			return nil, fmt.Errorf("zip321: memo not allowed for transparent address %q", p.Address)
+		}
+		raw, err := hex.DecodeString(*p.Memo)
+		if err != nil {
+			return nil, fmt.Errorf("zip321: memo for %q is not valid hex: %w", p.Address, err)
+		}
+		pairs = append(pairs, fmt.Sprintf("%s=%s", zip321Key("memo", idx), base64.RawURLEncoding.EncodeToString(raw)))
+	}
+	if p.Label != nil {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", zip321Key("label", idx), url.QueryEscape(*p.Label)))
+	}
+	return pairs, nil
+}
+
+// isTransparentAddress reports whether addr looks like a transparent (t-addr)
+// Zcash address on either mainnet (t1/t3) or testnet (tm/t2).
+func isTransparentAddress(addr string) bool {
+	for _, prefix := range []string{"t1", "t3", "tm", "t2"} {
+		if strings.HasPrefix(addr, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseZecAmount converts a decimal ZEC amount string (up to 8 decimal
+// places, per ZIP 321) to zatoshis using integer arithmetic only.
+func parseZecAmount(s string) (uint64, error) {
+	if strings.HasPrefix(s, "-") {
+		return 0, fmt.Errorf("amount %q must not be negative", s)
+	}
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	if whole == "" {
+		whole = "0"
+	}
+	wholeVal, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+	if wholeVal > math.MaxUint64/zatoshisPerZec {
+		return 0, fmt.Errorf("amount %q overflows zatoshis", s)
+	}
+	wholeZats := wholeVal * zatoshisPerZec
+
+	var fracVal uint64
+	if hasFrac {
+		if len(frac) > 8 {
+			return 0, fmt.Errorf("amount %q has more than 8 decimal places", s)
+		}
+		frac += strings.Repeat("0", 8-len(frac))
+		fracVal, err = strconv.ParseUint(frac, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid amount %q: %w", s, err)
+		}
+	}
+	// wholeVal*zatoshisPerZec alone can't overflow (checked above), but
+	// adding fracVal still can at the boundary, e.g. "184467440737.99999999".
+	if fracVal > math.MaxUint64-wholeZats {
+		return 0, fmt.Errorf("amount %q overflows zatoshis", s)
+	}
+
+	return wholeZats + fracVal, nil
+}
+
+// formatZecAmount converts zatoshis back to a decimal ZEC amount string,
+// trimming trailing fractional zeros.
+func formatZecAmount(zats uint64) string {
+	whole := zats / zatoshisPerZec
+	frac := zats % zatoshisPerZec
+	if frac == 0 {
+		return strconv.FormatUint(whole, 10)
+	}
+	fracStr := strings.TrimRight(fmt.Sprintf("%08d", frac), "0")
+	return fmt.Sprintf("%d.%s", whole, fracStr)
+}
@@ -0,0 +1,174 @@
+package t2z_uniffi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseZip321URISingleAddress(t *testing.T) {
+	req, err := ParseZip321URI("zcash:t1abc?amount=1.00000001&label=Lunch", "mainnet")
+	if err != nil {
+		t.Fatalf("ParseZip321URI: %v", err)
+	}
+	if len(req.Payments) != 1 {
+		t.Fatalf("expected 1 payment, got %d", len(req.Payments))
+	}
+	p := req.Payments[0]
+	if p.Address != "t1abc" {
+		t.Errorf("Address = %q", p.Address)
+	}
+	if p.Amount != 100000001 {
+		t.Errorf("Amount = %d, want 100000001", p.Amount)
+	}
+	if p.Label == nil || *p.Label != "Lunch" {
+		t.Errorf("Label = %v", p.Label)
+	}
+}
+
+func TestParseZip321URIMultiPayment(t *testing.T) {
+	uri := "zcash:?address=u1addr&amount=1&address.1=t1abc&amount.1=2.5"
+	req, err := ParseZip321URI(uri, "mainnet")
+	if err != nil {
+		t.Fatalf("ParseZip321URI: %v", err)
+	}
+	if len(req.Payments) != 2 {
+		t.Fatalf("expected 2 payments, got %d", len(req.Payments))
+	}
+	if req.Payments[0].Address != "u1addr" || req.Payments[0].Amount != zatoshisPerZec {
+		t.Errorf("payment 0 = %+v", req.Payments[0])
+	}
+	if req.Payments[1].Address != "t1abc" || req.Payments[1].Amount != 250000000 {
+		t.Errorf("payment 1 = %+v", req.Payments[1])
+	}
+}
+
+func TestParseZip321URIMemoDecodesToHex(t *testing.T) {
+	// base64url for "hi" is "aGk"
+	req, err := ParseZip321URI("zcash:u1addr?memo=aGk", "mainnet")
+	if err != nil {
+		t.Fatalf("ParseZip321URI: %v", err)
+	}
+	if req.Payments[0].Memo == nil || *req.Payments[0].Memo != "6869" {
+		t.Errorf("Memo = %v, want 6869", req.Payments[0].Memo)
+	}
+}
+
+func TestParseZip321URIRejectsMemoOnTransparentAddress(t *testing.T) {
+	_, err := ParseZip321URI("zcash:t1abc?memo=aGk", "mainnet")
+	if err == nil {
+		t.Error("expected error for memo on transparent address")
+	}
+}
+
+func TestParseZip321URIRejectsDuplicateParams(t *testing.T) {
+	_, err := ParseZip321URI("zcash:t1abc?amount=1&amount=2", "mainnet")
+	if err == nil {
+		t.Error("expected error for duplicate parameter")
+	}
+}
+
+func TestParseZip321URIRejectsNonContiguousIndices(t *testing.T) {
+	_, err := ParseZip321URI("zcash:?address=u1addr&address.2=t1abc&amount.2=1", "mainnet")
+	if err == nil {
+		t.Error("expected error for non-contiguous indices")
+	}
+}
+
+func TestParseZip321URIRejectsMissingScheme(t *testing.T) {
+	_, err := ParseZip321URI("t1abc?amount=1", "mainnet")
+	if err == nil {
+		t.Error("expected error for missing zcash: scheme")
+	}
+}
+
+func TestFormatZip321URISinglePayment(t *testing.T) {
+	req := UniffiTransactionRequest{
+		Payments: []UniffiPayment{
+			{Address: "t1abc", Amount: 100000001},
+		},
+	}
+	uri, err := FormatZip321URI(req, "mainnet")
+	if err != nil {
+		t.Fatalf("FormatZip321URI: %v", err)
+	}
+	if !strings.HasPrefix(uri, "zcash:t1abc?amount=1.00000001") {
+		t.Errorf("uri = %q", uri)
+	}
+}
+
+func TestFormatZip321URIMultiPayment(t *testing.T) {
+	req := UniffiTransactionRequest{
+		Payments: []UniffiPayment{
+			{Address: "u1addr", Amount: zatoshisPerZec},
+			{Address: "t1abc", Amount: 250000000},
+		},
+	}
+	uri, err := FormatZip321URI(req, "mainnet")
+	if err != nil {
+		t.Fatalf("FormatZip321URI: %v", err)
+	}
+	if !strings.Contains(uri, "address.1=t1abc") || !strings.Contains(uri, "amount.1=2.5") {
+		t.Errorf("uri = %q", uri)
+	}
+}
+
+func TestZip321RoundTrip(t *testing.T) {
+	memo := "6869"
+	original := UniffiTransactionRequest{
+		Payments: []UniffiPayment{
+			{Address: "u1addr", Amount: 123450000, Memo: &memo},
+		},
+	}
+	uri, err := FormatZip321URI(original, "mainnet")
+	if err != nil {
+		t.Fatalf("FormatZip321URI: %v", err)
+	}
+	parsed, err := ParseZip321URI(uri, "mainnet")
+	if err != nil {
+		t.Fatalf("ParseZip321URI(%q): %v", uri, err)
+	}
+	if len(parsed.Payments) != 1 {
+		t.Fatalf("expected 1 payment, got %d", len(parsed.Payments))
+	}
+	got := parsed.Payments[0]
+	if got.Address != original.Payments[0].Address || got.Amount != original.Payments[0].Amount {
+		t.Errorf("got %+v, want %+v", got, original.Payments[0])
+	}
+	if got.Memo == nil || *got.Memo != memo {
+		t.Errorf("Memo = %v, want %v", got.Memo, memo)
+	}
+}
+
+func TestParseZecAmount(t *testing.T) {
+	cases := map[string]uint64{
+		"0":          0,
+		"1":          zatoshisPerZec,
+		"1.00000001": zatoshisPerZec + 1,
+		"0.5":        zatoshisPerZec / 2,
+		"2.5":        2*zatoshisPerZec + zatoshisPerZec/2,
+	}
+	for in, want := range cases {
+		got, err := parseZecAmount(in)
+		if err != nil {
+			t.Errorf("parseZecAmount(%q): %v", in, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("parseZecAmount(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestParseZecAmountRejectsTooManyDecimals(t *testing.T) {
+	if _, err := parseZecAmount("1.123456789"); err == nil {
+		t.Error("expected error for more than 8 decimal places")
+	}
+}
+
+func TestParseZecAmountRejectsOverflowAtFractionalBoundary(t *testing.T) {
+	// wholeVal*zatoshisPerZec alone fits in a uint64, but adding fracVal
+	// wraps it; the whole-only overflow check must not miss this.
+	if _, err := parseZecAmount("184467440737.99999999"); err == nil {
+		t.Error("expected error for amount overflowing zatoshis at the fractional boundary")
+	}
+}